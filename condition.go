@@ -117,3 +117,18 @@ func Lte(column string, value interface{}) Builder {
 		return buildCmp(d, buf, "<=", column, value)
 	})
 }
+
+// wherePK creates a condition on the primary key of a struct.
+func wherePK(structValue interface{}) Builder {
+	v := reflect.Indirect(reflect.ValueOf(structValue))
+	var cond []Builder
+	if v.Kind() == reflect.Struct {
+		cond = primaryKey(v)
+	}
+	return BuildFunc(func(d Dialect, buf Buffer) error {
+		if len(cond) == 0 {
+			return ErrPrimaryKeyNotFound
+		}
+		return And(cond...).Build(d, buf)
+	})
+}
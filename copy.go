@@ -0,0 +1,219 @@
+package fjord
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"time"
+
+	"github.com/iktakahiro/fjord/dialect"
+)
+
+// defaultCopyBatchRows is the number of rows in one INSERT statement
+// when COPY is not supported by the dialect.
+const defaultCopyBatchRows = 1000
+
+// CopyBuilder loads rows with `COPY ... FROM STDIN` on PostgreSQL,
+// which is much faster than multi-row INSERT.
+// On MySQL, `LOAD DATA LOCAL INFILE` is used when LocalInfile is called.
+// Otherwise, rows are inserted with batched multi-row INSERT.
+//
+// COPY requires a transaction with lib/pq. When CopyBuilder is created by
+// a *Session, rows are loaded in a new transaction.
+type CopyBuilder struct {
+	runner
+	EventReceiver
+	Dialect Dialect
+
+	// BatchRows is the max number of rows in one INSERT statement
+	// when COPY is not supported.
+	BatchRows int
+
+	// LocalInfileReaders registers readers for `LOAD DATA LOCAL INFILE` on MySQL.
+	// When it is nil, rows are inserted with batched multi-row INSERT.
+	LocalInfileReaders *ReaderRegistry
+
+	insert *InsertBuilder
+}
+
+// CopyInto creates a CopyBuilder
+func (sess *Session) CopyInto(table string) *CopyBuilder {
+	return &CopyBuilder{
+		runner:        sess,
+		EventReceiver: sess,
+		Dialect:       sess.Dialect,
+		BatchRows:     defaultCopyBatchRows,
+		insert:        sess.InsertInto(table),
+	}
+}
+
+// CopyInto creates a CopyBuilder
+func (tx *Tx) CopyInto(table string) *CopyBuilder {
+	return &CopyBuilder{
+		runner:        tx,
+		EventReceiver: tx,
+		Dialect:       tx.Dialect,
+		BatchRows:     defaultCopyBatchRows,
+		insert:        tx.InsertInto(table),
+	}
+}
+
+func (b *CopyBuilder) Columns(column ...string) *CopyBuilder {
+	b.insert.Columns(column...)
+	return b
+}
+
+func (b *CopyBuilder) Values(value ...interface{}) *CopyBuilder {
+	b.insert.Values(value...)
+	return b
+}
+
+func (b *CopyBuilder) Record(structValue interface{}) *CopyBuilder {
+	b.insert.Record(structValue)
+	return b
+}
+
+func (b *CopyBuilder) Records(slice interface{}) *CopyBuilder {
+	b.insert.Records(slice)
+	return b
+}
+
+// LocalInfile loads rows with `LOAD DATA LOCAL INFILE` on MySQL, streaming them
+// through a reader registered to the driver with registry.
+// The server must enable local_infile. It runs in the session or transaction
+// of the builder.
+func (b *CopyBuilder) LocalInfile(registry ReaderRegistry) *CopyBuilder {
+	b.LocalInfileReaders = &registry
+	return b
+}
+
+// Exec loads rows, and returns the number of rows as RowsAffected.
+// Values which must be written in SQL, such as DEFAULT for fields tagged with
+// omitempty or NOW() for autocreate fields without Clock, cannot be sent with
+// COPY or LOAD DATA.
+func (b *CopyBuilder) Exec() (sql.Result, error) {
+	if b.Dialect != dialect.PostgreSQL && !(b.Dialect == dialect.MySQL && b.LocalInfileReaders != nil) {
+		return b.insert.BatchRows(b.BatchRows).Exec()
+	}
+
+	stmt := b.insert.InsertStmt
+	if b.insert.err != nil {
+		return nil, b.EventErrKv("fjord.copy.record", b.insert.err, kvs{
+			"table": stmt.Table,
+		})
+	}
+	if stmt.Table == "" {
+		return nil, ErrTableNotSpecified
+	}
+	if len(stmt.Column) == 0 {
+		return nil, ErrColumnNotSpecified
+	}
+
+	var query string
+	var load func() (sql.Result, error)
+	if b.Dialect == dialect.PostgreSQL {
+		query = copyQuery(b.Dialect, stmt.Table, stmt.Column)
+		load = func() (sql.Result, error) {
+			if err := b.execCopy(query, stmt.Value); err != nil {
+				return nil, err
+			}
+			return driver.RowsAffected(len(stmt.Value)), nil
+		}
+	} else {
+		name := loadDataReaderName()
+		query = loadDataQuery(b.Dialect, name, stmt.Table, stmt.Column)
+		load = func() (sql.Result, error) {
+			return b.execLoadData(name, query, stmt.Value)
+		}
+	}
+
+	startTime := time.Now()
+	defer func() {
+		b.TimingKv("fjord.copy", time.Since(startTime).Nanoseconds(), kvs{
+			"sql":  query,
+			"rows": fmt.Sprint(len(stmt.Value)),
+		})
+	}()
+
+	result, err := load()
+	if err != nil {
+		return nil, b.EventErrKv("fjord.copy.exec", err, kvs{
+			"sql": query,
+		})
+	}
+
+	for _, record := range b.insert.record {
+		if err := afterInsert(b.runner, record); err != nil {
+			return result, b.EventErrKv("fjord.copy.hook", err, kvs{
+				"table": stmt.Table,
+			})
+		}
+	}
+	return result, nil
+}
+
+func (b *CopyBuilder) execCopy(query string, value [][]interface{}) error {
+	// rows are checked before COPY starts, so that no row is written on error
+	for _, row := range value {
+		for _, v := range row {
+			if _, ok := v.(Builder); ok {
+				return ErrNotSupported
+			}
+		}
+	}
+
+	var tx *Tx
+	switch r := b.runner.(type) {
+	case *Tx:
+		tx = r
+	case *Session:
+		var err error
+		tx, err = r.Begin()
+		if err != nil {
+			return err
+		}
+		defer tx.RollbackUnlessCommitted()
+	default:
+		return ErrNotSupported
+	}
+
+	ps, err := tx.PrepareContext(tx.ctx, query)
+	if err != nil {
+		return err
+	}
+	defer ps.Close()
+
+	for _, row := range value {
+		if _, err := ps.ExecContext(tx.ctx, row...); err != nil {
+			return err
+		}
+	}
+	// flush buffered rows
+	if _, err := ps.ExecContext(tx.ctx); err != nil {
+		return err
+	}
+	if err := ps.Close(); err != nil {
+		return err
+	}
+
+	if tx != b.runner {
+		return tx.Commit()
+	}
+	return nil
+}
+
+// copyQuery builds `COPY table (column) FROM STDIN`
+func copyQuery(d Dialect, table string, column []string) string {
+	buf := NewBuffer()
+	buf.WriteString("COPY ")
+	buf.WriteString(d.QuoteIdent(table))
+	buf.WriteString(" (")
+	for i, col := range column {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(d.QuoteIdent(col))
+	}
+	buf.WriteString(") FROM STDIN")
+	return buf.String()
+}
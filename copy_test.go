@@ -0,0 +1,34 @@
+package fjord
+
+import (
+	"testing"
+
+	"github.com/iktakahiro/fjord/dialect"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCopyQuery(t *testing.T) {
+	assert.Equal(t, `COPY "person" ("id", "name") FROM STDIN`,
+		copyQuery(dialect.PostgreSQL, "person", []string{"id", "name"}))
+}
+
+func TestCopyBuilderRecordsError(t *testing.T) {
+	sess := (&Connection{Dialect: dialect.PostgreSQL, EventReceiver: nullReceiver}).NewSession(nil)
+
+	_, err := sess.CopyInto("person").Columns("id").Records(Person{}).Exec()
+	assert.Equal(t, ErrInvalidRecords, err)
+
+	_, err = sess.CopyInto("person").Records([]Person{}).Exec()
+	assert.Equal(t, ErrColumnNotSpecified, err)
+}
+
+func TestCopyBuilderBuilderValue(t *testing.T) {
+	// the transaction is never used, because rows are checked before COPY starts
+	tx := &Tx{EventReceiver: nullReceiver, Dialect: dialect.PostgreSQL}
+
+	_, err := tx.CopyInto("person").Columns("id", "name").
+		Values(1, "one").
+		Values(2, Default).
+		Exec()
+	assert.Equal(t, ErrNotSupported, err)
+}
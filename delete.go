@@ -1,5 +1,7 @@
 package fjord
 
+import "github.com/iktakahiro/fjord/dialect"
+
 // DeleteStmt builds `DELETE ...`
 type DeleteStmt struct {
 	raw
@@ -7,6 +9,8 @@ type DeleteStmt struct {
 	Table string
 
 	WhereCond []Builder
+
+	joinTable []joinTable
 }
 
 // Build builds `DELETE ...` in dialect
@@ -19,12 +23,31 @@ func (b *DeleteStmt) Build(d Dialect, buf Buffer) error {
 		return ErrTableNotSpecified
 	}
 
-	buf.WriteString("DELETE FROM ")
-	buf.WriteString(d.QuoteIdent(b.Table))
+	cond := b.WhereCond
+	switch {
+	case len(b.joinTable) == 0:
+		buf.WriteString("DELETE FROM ")
+		buf.WriteString(d.QuoteIdent(b.Table))
+	case d == dialect.MySQL:
+		buf.WriteString("DELETE ")
+		buf.WriteString(d.QuoteIdent(b.Table))
+		buf.WriteString(" FROM ")
+		buf.WriteString(d.QuoteIdent(b.Table))
+		if err := buildJoinTables(d, buf, b.joinTable); err != nil {
+			return err
+		}
+	case d == dialect.PostgreSQL:
+		buf.WriteString("DELETE FROM ")
+		buf.WriteString(d.QuoteIdent(b.Table))
+		buf.WriteString(" USING ")
+		cond = append(buildTableList(d, buf, b.joinTable), cond...)
+	default:
+		return ErrNotSupported
+	}
 
-	if len(b.WhereCond) > 0 {
+	if len(cond) > 0 {
 		buf.WriteString(" WHERE ")
-		err := And(b.WhereCond...).Build(d, buf)
+		err := And(cond...).Build(d, buf)
 		if err != nil {
 			return err
 		}
@@ -49,6 +72,14 @@ func DeleteBySql(query string, value ...interface{}) *DeleteStmt {
 	}
 }
 
+// Join joins a table to delete rows which match another table.
+// It is `DELETE a FROM a JOIN b ON ...` in MySQL,
+// and `DELETE FROM a USING b WHERE ...` in PostgreSQL.
+func (b *DeleteStmt) Join(table, on interface{}) *DeleteStmt {
+	b.joinTable = append(b.joinTable, joinTable{table: table, on: on})
+	return b
+}
+
 // Where adds a where condition
 func (b *DeleteStmt) Where(query interface{}, value ...interface{}) *DeleteStmt {
 	switch query := query.(type) {
@@ -13,6 +13,10 @@ type DeleteBuilder struct {
 	*DeleteStmt
 
 	LimitCount int64
+
+	// RequireWhere makes Exec fail without WHERE unless IsAllRows is set
+	RequireWhere bool
+	IsAllRows    bool
 }
 
 func (sess *Session) DeleteFrom(table string) *DeleteBuilder {
@@ -22,6 +26,7 @@ func (sess *Session) DeleteFrom(table string) *DeleteBuilder {
 		Dialect:       sess.Dialect,
 		DeleteStmt:    DeleteFrom(table),
 		LimitCount:    -1,
+		RequireWhere:  sess.RequireWhere,
 	}
 }
 
@@ -32,6 +37,7 @@ func (tx *Tx) DeleteFrom(table string) *DeleteBuilder {
 		Dialect:       tx.Dialect,
 		DeleteStmt:    DeleteFrom(table),
 		LimitCount:    -1,
+		RequireWhere:  tx.RequireWhere,
 	}
 }
 
@@ -42,6 +48,7 @@ func (sess *Session) DeleteBySql(query string, value ...interface{}) *DeleteBuil
 		Dialect:       sess.Dialect,
 		DeleteStmt:    DeleteBySql(query, value...),
 		LimitCount:    -1,
+		RequireWhere:  sess.RequireWhere,
 	}
 }
 
@@ -52,13 +59,35 @@ func (tx *Tx) DeleteBySql(query string, value ...interface{}) *DeleteBuilder {
 		Dialect:       tx.Dialect,
 		DeleteStmt:    DeleteBySql(query, value...),
 		LimitCount:    -1,
+		RequireWhere:  tx.RequireWhere,
 	}
 }
 
 func (b *DeleteBuilder) Exec() (sql.Result, error) {
+	if b.unbounded() {
+		return nil, b.EventErrKv("fjord.delete.unbounded", ErrMissingWhere, kvs{
+			"table": b.Table,
+		})
+	}
 	return exec(b.runner, b.EventReceiver, b, b.Dialect)
 }
 
+// AllRows allows Exec without WHERE when RequireWhere is set.
+func (b *DeleteBuilder) AllRows() *DeleteBuilder {
+	b.IsAllRows = true
+	return b
+}
+
+// unbounded reports whether the statement would delete all rows against RequireWhere
+func (b *DeleteBuilder) unbounded() bool {
+	return b.RequireWhere && !b.IsAllRows && b.raw.Query == "" && len(b.WhereCond) == 0
+}
+
+func (b *DeleteBuilder) Join(table, on interface{}) *DeleteBuilder {
+	b.DeleteStmt.Join(table, on)
+	return b
+}
+
 func (b *DeleteBuilder) Where(query interface{}, value ...interface{}) *DeleteBuilder {
 	b.DeleteStmt.Where(query, value...)
 	return b
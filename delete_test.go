@@ -16,9 +16,48 @@ func TestDeleteStmt(t *testing.T) {
 	assert.Equal(t, []interface{}{1}, buf.Value())
 }
 
+func TestDeleteStmtJoin(t *testing.T) {
+	for _, test := range []struct {
+		d     Dialect
+		query string
+	}{
+		{
+			d: dialect.MySQL,
+			query: "DELETE `person` FROM `person` JOIN `role` ON person.id = role.person_id " +
+				"WHERE (`role`.`name` = 'guest')",
+		},
+		{
+			d: dialect.PostgreSQL,
+			query: `DELETE FROM "person" USING "role" ` +
+				`WHERE (person.id = role.person_id) AND ("role"."name" = 'guest')`,
+		},
+	} {
+		stmt := DeleteFrom("person").
+			Join("role", "person.id = role.person_id").
+			Where(Eq("role.name", "guest"))
+		query, err := InterpolateForDialect("?", []interface{}{stmt}, test.d)
+		assert.NoError(t, err)
+		assert.Equal(t, test.query, query)
+	}
+}
+
 func BenchmarkDeleteSQL(b *testing.B) {
 	buf := NewBuffer()
 	for i := 0; i < b.N; i++ {
 		DeleteFrom("table").Where(Eq("a", 1)).Build(dialect.MySQL, buf)
 	}
 }
+
+func TestDeleteBuilderRequireWhere(t *testing.T) {
+	log := &errorEventRecorder{}
+	sess := (&Connection{Dialect: dialect.MySQL, EventReceiver: log, RequireWhere: true}).NewSession(nil)
+
+	_, err := sess.DeleteFrom("table").Exec()
+	assert.Equal(t, ErrMissingWhere, err)
+	assert.Equal(t, []string{"fjord.delete.unbounded"}, log.event)
+
+	assert.True(t, sess.DeleteFrom("table").Join("other", Expr("table.id = other.id")).unbounded())
+	assert.False(t, sess.DeleteFrom("table").Where(Eq("a", 1)).unbounded())
+	assert.False(t, sess.DeleteFrom("table").AllRows().unbounded())
+	assert.False(t, sess.DeleteBySql("DELETE FROM `table`").unbounded())
+}
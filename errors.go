@@ -13,4 +13,17 @@ var (
 	ErrInvalidSliceLength = errors.New("fjord: length of slice is 0. length must be >= 1")
 	ErrCantConvertToTime  = errors.New("fjord: can't convert to time.Time")
 	ErrInvalidTimestring  = errors.New("fjord: invalid time string")
+
+	ErrRelationKeyNotFound = errors.New("fjord: key column of relation not found in result columns")
+	ErrInvalidPreload      = errors.New("fjord: preload field must be a slice of struct referenced by the primary key")
+	ErrPrimaryKeyNotFound  = errors.New("fjord: no field is tagged with pk")
+	ErrNotTracked          = errors.New("fjord: struct does not embed fjord.Tracked")
+	ErrPrimaryKeyCount     = errors.New("fjord: wrong number of primary key values")
+	ErrRecordType          = errors.New("fjord: record type does not match the table")
+	ErrStaleObject         = errors.New("fjord: record was modified or deleted by another update")
+	ErrInvalidRecords      = errors.New("fjord: records must be a slice of struct or pointer to struct")
+	ErrInsertSource        = errors.New("fjord: insert takes only one of values, a select statement or default values")
+	ErrColumnCount         = errors.New("fjord: numbers of insert columns and select columns differ")
+	ErrMissingWhere        = errors.New("fjord: update or delete without where condition; call AllRows to affect all rows")
+	ErrArgumentCount       = errors.New("fjord: wrong number of function arguments")
 )
@@ -41,6 +41,17 @@ type Connection struct {
 	*sql.DB
 	Dialect Dialect
 	EventReceiver
+
+	// StrictMode is the default strict mapping mode for loading results
+	StrictMode StrictMode
+
+	// Clock returns the time for fields tagged with autocreate or autoupdate.
+	// When it is nil, NOW() of the database is used.
+	Clock func() time.Time
+
+	// RequireWhere makes UPDATE and DELETE without WHERE fail with ErrMissingWhere
+	// unless AllRows is called.
+	RequireWhere bool
 }
 
 // Session represents a business unit of execution for some connection
@@ -146,7 +157,7 @@ func exec(runner runner, log EventReceiver, builder Builder, d Dialect) (sql.Res
 	return result, nil
 }
 
-func query(runner runner, log EventReceiver, builder Builder, d Dialect, dest interface{}) (int, error) {
+func query(runner runner, log EventReceiver, builder Builder, d Dialect, dest interface{}, mode StrictMode) (int, error) {
 	i := interpolator{
 		Buffer:       NewBuffer(),
 		Dialect:      d,
@@ -175,11 +186,19 @@ func query(runner runner, log EventReceiver, builder Builder, d Dialect, dest in
 		})
 	}
 
-	count, err := load(rows, dest)
+	start := loadedLen(dest)
+	count, err := load(rows, dest, mode)
 	if err != nil {
 		return 0, log.EventErrKv("fjord.select.load.scan", err, kvs{
 			"sql": query,
 		})
 	}
+	if count > 0 {
+		if err := afterLoad(runner, dest, start); err != nil {
+			return count, log.EventErrKv("fjord.select.load.hook", err, kvs{
+				"sql": query,
+			})
+		}
+	}
 	return count, nil
 }
@@ -101,6 +101,26 @@ func resetDB(conn *Connection) {
 			name VARCHAR(255) NOT NULL
 		)`,
 
+		`DROP TABLE IF EXISTS json_types`,
+		`CREATE TABLE json_types (
+			id BIGINT,
+			payload TEXT NULL
+		)`,
+
+		`DROP TABLE IF EXISTS soft_person`,
+		`CREATE TABLE soft_person (
+			id BIGINT,
+			name varchar(255) NOT NULL,
+			deleted_at TIMESTAMP NULL
+		)`,
+
+		`DROP TABLE IF EXISTS versioned_person`,
+		`CREATE TABLE versioned_person (
+			id BIGINT,
+			name varchar(255) NOT NULL,
+			version INT NOT NULL
+		)`,
+
 		`DROP TABLE IF EXISTS null_types`,
 		fmt.Sprintf(`CREATE TABLE null_types (
 			id %s,
@@ -229,6 +249,767 @@ func TestJoin(t *testing.T) {
 	}
 }
 
+type PersonWithRoles struct {
+	PersonWithTag
+	Roles []RoleWithTag `db:"r" key:"p.id"`
+}
+
+func TestHasManyJoin(t *testing.T) {
+	for _, conn := range testConnections {
+		sess := conn.NewSession(nil)
+
+		for _, person := range []*PersonWithTag{{ID: 3001, Name: "Alice"}, {ID: 3002, Name: "Bob"}} {
+			_, err := sess.InsertInto("person2").Columns("id", "name").Record(person).Exec()
+			assert.NoError(t, err)
+		}
+		for _, role := range []*RoleWithTag{{PersonID: 3001, Name: "Admin"}, {PersonID: 3001, Name: "Editor"}} {
+			_, err := sess.InsertInto("role").Columns("person_id", "name").Record(role).Exec()
+			assert.NoError(t, err)
+		}
+
+		var persons []PersonWithRoles
+		count, err := sess.Select(I("p.id"), I("p.name"), I("r.person_id"), I("r.name")).
+			From(I("person2").As("p")).
+			LeftJoin(I("role").As("r"), "p.id = r.person_id").
+			Where(Eq("p.id", []int{3001, 3002})).
+			OrderBy("p.id").
+			OrderBy("r.name").
+			Load(&persons)
+		assert.NoError(t, err)
+		if assert.Equal(t, 2, count) {
+			assert.Equal(t, "Alice", persons[0].Name)
+			assert.Equal(t, []RoleWithTag{{3001, "Admin"}, {3001, "Editor"}}, persons[0].Roles)
+			assert.Equal(t, "Bob", persons[1].Name)
+			assert.Empty(t, persons[1].Roles)
+		}
+	}
+}
+
+type RoleWithNamePtr struct {
+	PersonID int64   `db:"person_id"`
+	Name     *string `db:"name"`
+}
+
+type PersonWithTwoRoleLists struct {
+	PersonWithTag
+	Roles  []RoleWithNamePtr `db:"r" key:"p.id"`
+	Others []RoleWithNamePtr `db:"o" key:"p.id"`
+}
+
+func TestHasManyJoinPointerFields(t *testing.T) {
+	for _, conn := range testConnections {
+		sess := conn.NewSession(nil)
+
+		_, err := sess.InsertInto("person2").Columns("id", "name").Values(3011, "Alice").Exec()
+		assert.NoError(t, err)
+		_, err = sess.InsertInto("role").Columns("person_id", "name").Values(3011, "Admin").Values(3011, "Editor").Exec()
+		assert.NoError(t, err)
+
+		// each child appears in two rows of the cross join
+		var person PersonWithTwoRoleLists
+		count, err := sess.Select(I("p.id"), I("p.name"), I("r.person_id"), I("r.name"), I("o.person_id"), I("o.name")).
+			From(I("person2").As("p")).
+			Join(I("role").As("r"), "p.id = r.person_id").
+			Join(I("role").As("o"), "p.id = o.person_id").
+			Where(Eq("p.id", 3011)).
+			OrderBy("r.name").
+			OrderBy("o.name").
+			Load(&person)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, count)
+		if assert.Len(t, person.Roles, 2) && assert.Len(t, person.Others, 2) {
+			assert.Equal(t, "Admin", *person.Roles[0].Name)
+			assert.Equal(t, "Editor", *person.Roles[1].Name)
+			assert.Equal(t, "Admin", *person.Others[0].Name)
+			assert.Equal(t, "Editor", *person.Others[1].Name)
+		}
+	}
+}
+
+type Role struct {
+	PersonID int64
+	Name     string
+}
+
+type PersonWithRoleList struct {
+	ID    int64
+	Name  string
+	Roles []Role `db:"-"`
+}
+
+func TestPreload(t *testing.T) {
+	for _, conn := range testConnections {
+		sess := conn.NewSession(nil)
+
+		_, err := sess.InsertInto("person2").Columns("id", "name").Values(4001, "Alice").Values(4002, "Bob").Exec()
+		assert.NoError(t, err)
+		_, err = sess.InsertInto("role").Columns("person_id", "name").Values(4001, "Admin").Values(4001, "Editor").Exec()
+		assert.NoError(t, err)
+
+		var persons []PersonWithRoleList
+		_, err = sess.Select("*").From("person2").Where(Eq("id", []int{4001, 4002})).OrderBy("id").Load(&persons)
+		assert.NoError(t, err)
+
+		count, err := sess.Preload(&persons, "Roles", "person_id").Load()
+		assert.NoError(t, err)
+		assert.Equal(t, 2, count)
+		if assert.Len(t, persons, 2) {
+			assert.Len(t, persons[0].Roles, 2)
+			assert.Empty(t, persons[1].Roles)
+		}
+	}
+}
+
+type PersonNoWithRoleList struct {
+	PersonNo int64 `db:"person_no,pk"`
+	Name     string
+	Roles    []Role `db:"-"`
+}
+
+func TestPreloadByPrimaryKeyTag(t *testing.T) {
+	for _, conn := range testConnections {
+		sess := conn.NewSession(nil)
+
+		_, err := sess.InsertInto("person2").Columns("id", "name").Values(4011, "Alice").Exec()
+		assert.NoError(t, err)
+		_, err = sess.InsertInto("role").Columns("person_id", "name").Values(4011, "Admin").Exec()
+		assert.NoError(t, err)
+
+		var persons []PersonNoWithRoleList
+		_, err = sess.Select("id AS person_no", "name").From("person2").Where(Eq("id", 4011)).Load(&persons)
+		assert.NoError(t, err)
+
+		count, err := sess.Preload(&persons, "Roles", "person_id").Load()
+		assert.NoError(t, err)
+		assert.Equal(t, 1, count)
+		if assert.Len(t, persons, 1) {
+			assert.Equal(t, []Role{{4011, "Admin"}}, persons[0].Roles)
+		}
+	}
+}
+
+type JSONRecord struct {
+	ID      int64
+	Payload map[string]string `db:"payload,json"`
+}
+
+func TestJSONColumn(t *testing.T) {
+	for _, conn := range testConnections {
+		sess := conn.NewSession(nil)
+
+		id := nextID()
+		record := &JSONRecord{
+			ID:      id,
+			Payload: map[string]string{"title": "It's JSON"},
+		}
+		_, err := sess.InsertInto("json_types").Columns("id", "payload").Record(record).Exec()
+		assert.NoError(t, err)
+
+		var loaded JSONRecord
+		_, err = sess.Select("*").From("json_types").Where(Eq("id", id)).Load(&loaded)
+		assert.NoError(t, err)
+		assert.Equal(t, record.Payload, loaded.Payload)
+
+		_, err = sess.Update("json_types").Set("payload", JSON(nil)).Where(Eq("id", id)).Exec()
+		assert.NoError(t, err)
+
+		_, err = sess.Select("*").From("json_types").Where(Eq("id", id)).Load(&loaded)
+		assert.NoError(t, err)
+		assert.Nil(t, loaded.Payload)
+	}
+}
+
+type TrackedPerson struct {
+	Tracked
+	ID    int64 `db:"id,pk"`
+	Name  string
+	Email string
+}
+
+func TestUpdateChanged(t *testing.T) {
+	for _, conn := range testConnections {
+		sess := conn.NewSession(nil)
+
+		id := nextID()
+		_, err := sess.InsertInto("person").Columns("id", "name", "email").Values(id, "John Titor", "john@example.com").Exec()
+		assert.NoError(t, err)
+
+		var person TrackedPerson
+		_, err = sess.Select("*").From("person").Where(Eq("id", id)).Load(&person)
+		assert.NoError(t, err)
+
+		// nothing changed
+		result, err := sess.UpdateChanged("person", &person)
+		assert.NoError(t, err)
+		rowsAffected, err := result.RowsAffected()
+		assert.NoError(t, err)
+		assert.EqualValues(t, 0, rowsAffected)
+
+		person.Name = "John Tailor"
+		result, err = sess.UpdateChanged("person", &person)
+		assert.NoError(t, err)
+		rowsAffected, err = result.RowsAffected()
+		assert.NoError(t, err)
+		assert.EqualValues(t, 1, rowsAffected)
+
+		var loaded TrackedPerson
+		_, err = sess.Select("*").From("person").Where(Eq("id", id)).Load(&loaded)
+		assert.NoError(t, err)
+		assert.Equal(t, "John Tailor", loaded.Name)
+		assert.Equal(t, "john@example.com", loaded.Email)
+
+		_, err = sess.UpdateChanged("person", &Person{ID: id})
+		assert.Equal(t, ErrNotTracked, err)
+	}
+}
+
+type VersionedPerson struct {
+	ID      int64 `db:"id,pk"`
+	Name    string
+	Version int `db:"version,version"`
+}
+
+func TestOptimisticLocking(t *testing.T) {
+	for _, conn := range testConnections {
+		sess := conn.NewSession(nil)
+
+		id := nextID()
+		_, err := sess.InsertInto("versioned_person").Columns("id", "name", "version").Values(id, "John Titor", 1).Exec()
+		assert.NoError(t, err)
+
+		var person, stale VersionedPerson
+		_, err = sess.Select("*").From("versioned_person").Where(Eq("id", id)).Load(&person)
+		assert.NoError(t, err)
+		_, err = sess.Select("*").From("versioned_person").Where(Eq("id", id)).Load(&stale)
+		assert.NoError(t, err)
+
+		person.Name = "John Tailor"
+		_, err = sess.Update("versioned_person").SetRecord(&person).WherePK(&person).Exec()
+		assert.NoError(t, err)
+		assert.Equal(t, 2, person.Version)
+
+		stale.Name = "John Doe"
+		_, err = sess.Update("versioned_person").SetRecord(&stale).WherePK(&stale).Exec()
+		assert.Equal(t, ErrStaleObject, err)
+		assert.Equal(t, 1, stale.Version)
+
+		var loaded VersionedPerson
+		_, err = sess.Select("*").From("versioned_person").Where(Eq("id", id)).Load(&loaded)
+		assert.NoError(t, err)
+		assert.Equal(t, "John Tailor", loaded.Name)
+		assert.Equal(t, 2, loaded.Version)
+	}
+}
+
+type HookPerson struct {
+	ID       int64 `db:"id"`
+	Name     string
+	Inserted bool `db:"-"`
+	Loaded   bool `db:"-"`
+}
+
+func (p *HookPerson) BeforeInsert(runner SessionRunner) error {
+	if p.Name == "" {
+		return errInvalidHookRecord
+	}
+	return nil
+}
+
+func (p *HookPerson) AfterInsert(runner SessionRunner) error {
+	p.Inserted = true
+	return nil
+}
+
+func (p *HookPerson) AfterLoad(runner SessionRunner) error {
+	p.Loaded = true
+	return nil
+}
+
+func TestHooks(t *testing.T) {
+	for _, conn := range testConnections {
+		sess := conn.NewSession(nil)
+
+		person := &HookPerson{ID: nextID()}
+		_, err := sess.InsertInto("person").Columns("id", "name").Record(person).Exec()
+		assert.Equal(t, errInvalidHookRecord, err)
+		assert.False(t, person.Inserted)
+
+		person.Name = "John Titor"
+		_, err = sess.InsertInto("person").Columns("id", "name").Record(person).Exec()
+		assert.NoError(t, err)
+		assert.True(t, person.Inserted)
+
+		var persons []HookPerson
+		count, err := sess.Select("id", "name").From("person").Where(Eq("id", person.ID)).Load(&persons)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, count)
+		assert.True(t, persons[0].Loaded)
+	}
+}
+
+func TestInsertBatch(t *testing.T) {
+	for _, conn := range testConnections {
+		sess := conn.NewSession(nil)
+
+		name := fmt.Sprintf("batch-%d", nextID())
+		stmt := sess.InsertInto("person").Columns("id", "name").BatchParams(4).InTransaction()
+		for i := 0; i < 5; i++ {
+			stmt.Values(nextID(), name)
+		}
+		result, err := stmt.Exec()
+		assert.NoError(t, err)
+		rowsAffected, err := result.RowsAffected()
+		assert.NoError(t, err)
+		assert.EqualValues(t, 5, rowsAffected)
+
+		var count int
+		_, err = sess.Select("COUNT(*)").From("person").Where(Eq("name", name)).Load(&count)
+		assert.NoError(t, err)
+		assert.Equal(t, 5, count)
+	}
+}
+
+func TestInsertRecords(t *testing.T) {
+	for _, conn := range testConnections {
+		sess := conn.NewSession(nil)
+
+		persons := []Person{
+			{Name: "Barack"},
+			{Name: "Obama"},
+		}
+		_, err := sess.InsertInto("person").Columns("name").Records(persons).Exec()
+		assert.NoError(t, err)
+		assert.NotZero(t, persons[0].ID)
+		assert.Equal(t, persons[0].ID+1, persons[1].ID)
+
+		var loaded Person
+		_, err = sess.Select("*").From("person").Where(Eq("id", persons[1].ID)).Load(&loaded)
+		assert.NoError(t, err)
+		assert.Equal(t, "Obama", loaded.Name)
+	}
+}
+
+func TestInsertRecordsIgnore(t *testing.T) {
+	for _, conn := range testConnections {
+		sess := conn.NewSession(nil)
+
+		id := nextID()
+		_, err := sess.InsertInto("person").Columns("id", "name").Values(id, "Barack").Exec()
+		assert.NoError(t, err)
+
+		// the first record conflicts, and only the second one is inserted
+		newID := nextID()
+		persons := []Person{
+			{ID: id, Name: "Barack"},
+			{ID: newID, Name: "Michelle"},
+		}
+		result, err := sess.InsertInto("person").Columns("id", "name").Records(persons).Ignore().Exec()
+		assert.NoError(t, err)
+		rowsAffected, err := result.RowsAffected()
+		assert.NoError(t, err)
+		assert.EqualValues(t, 1, rowsAffected)
+		assert.Equal(t, id, persons[0].ID)
+		assert.Equal(t, newID, persons[1].ID)
+	}
+}
+
+func TestCopyInto(t *testing.T) {
+	for _, conn := range testConnections {
+		sess := conn.NewSession(nil)
+
+		name := fmt.Sprintf("copy-%d", nextID())
+		persons := []Person{
+			{ID: nextID(), Name: name, Email: "barack@example.com"},
+			{ID: nextID(), Name: name},
+		}
+		result, err := sess.CopyInto("person").Columns("id", "name", "email").Records(persons).Exec()
+		assert.NoError(t, err)
+		rowsAffected, err := result.RowsAffected()
+		assert.NoError(t, err)
+		assert.EqualValues(t, 2, rowsAffected)
+
+		var loaded []Person
+		count, err := sess.Select("*").From("person").Where(Eq("name", name)).OrderBy("id").Load(&loaded)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, count)
+		assert.Equal(t, persons, loaded)
+	}
+}
+
+func TestInsertFromSelect(t *testing.T) {
+	for _, conn := range testConnections {
+		sess := conn.NewSession(nil)
+
+		id := nextID()
+		_, err := sess.InsertInto("person").Columns("id", "name").Values(id, "Barack").Exec()
+		assert.NoError(t, err)
+
+		result, err := sess.InsertInto("person2").
+			Columns("id", "name").
+			FromSelect(Select("id", "name").From("person").Where(Eq("id", id))).
+			Exec()
+		assert.NoError(t, err)
+		rowsAffected, err := result.RowsAffected()
+		assert.NoError(t, err)
+		assert.EqualValues(t, 1, rowsAffected)
+
+		var name string
+		_, err = sess.Select("name").From("person2").Where(Eq("id", id)).Load(&name)
+		assert.NoError(t, err)
+		assert.Equal(t, "Barack", name)
+	}
+}
+
+func TestInsertIgnore(t *testing.T) {
+	for _, conn := range testConnections {
+		sess := conn.NewSession(nil)
+
+		result, err := sess.InsertInto("null_types").DefaultValues().Exec()
+		assert.NoError(t, err)
+		rowsAffected, err := result.RowsAffected()
+		assert.NoError(t, err)
+		assert.EqualValues(t, 1, rowsAffected)
+
+		id := nextID()
+		_, err = sess.InsertInto("person").Columns("id", "name").Values(id, "Barack").Exec()
+		assert.NoError(t, err)
+
+		result, err = sess.InsertInto("person").Columns("id", "name").Values(id, "Obama").Ignore().Exec()
+		assert.NoError(t, err)
+		rowsAffected, err = result.RowsAffected()
+		assert.NoError(t, err)
+		assert.EqualValues(t, 0, rowsAffected)
+	}
+}
+
+func TestUpdateDeleteJoin(t *testing.T) {
+	for _, conn := range testConnections {
+		sess := conn.NewSession(nil)
+
+		id := nextID()
+		_, err := sess.InsertInto("person").Columns("id", "name").Values(id, "Barack").Exec()
+		assert.NoError(t, err)
+		_, err = sess.InsertInto("role").Columns("person_id", "name").Values(id, "guest").Exec()
+		assert.NoError(t, err)
+
+		_, err = sess.Update("person").
+			Join("role", Expr("person.id = role.person_id")).
+			Set("email", "guest@example.com").
+			Where(Eq("role.name", "guest")).
+			Where(Eq("person.id", id)).
+			Exec()
+		assert.NoError(t, err)
+
+		var email string
+		_, err = sess.Select("email").From("person").Where(Eq("id", id)).Load(&email)
+		assert.NoError(t, err)
+		assert.Equal(t, "guest@example.com", email)
+
+		result, err := sess.DeleteFrom("person").
+			Join("role", Expr("person.id = role.person_id")).
+			Where(Eq("role.name", "guest")).
+			Where(Eq("person.id", id)).
+			Exec()
+		assert.NoError(t, err)
+		rowsAffected, err := result.RowsAffected()
+		assert.NoError(t, err)
+		assert.EqualValues(t, 1, rowsAffected)
+	}
+}
+
+func TestUpdateSetExpr(t *testing.T) {
+	for _, conn := range testConnections {
+		sess := conn.NewSession(nil)
+
+		id := nextID()
+		_, err := sess.InsertInto("null_types").Columns("id", "int64_val").Values(id, 1).Exec()
+		assert.NoError(t, err)
+		_, err = sess.InsertInto("person2").Columns("id", "name").Values(id, "Barack").Exec()
+		assert.NoError(t, err)
+
+		_, err = sess.Update("null_types").
+			Increment("int64_val", 2).
+			SetSelect("string_val", Select("name").From("person2").Where(Eq("id", id))).
+			Where(Eq("id", id)).
+			Exec()
+		assert.NoError(t, err)
+
+		var nullTypes nullTypedRecord
+		_, err = sess.Select("*").From("null_types").Where(Eq("id", id)).Load(&nullTypes)
+		assert.NoError(t, err)
+		assert.EqualValues(t, 3, nullTypes.Int64Val.Int64)
+		assert.Equal(t, "Barack", nullTypes.StringVal.String)
+
+		_, err = sess.Update("null_types").Decrement("int64_val", 1).SetExpr("string_val", "UPPER(string_val)").Where(Eq("id", id)).Exec()
+		assert.NoError(t, err)
+
+		_, err = sess.Select("*").From("null_types").Where(Eq("id", id)).Load(&nullTypes)
+		assert.NoError(t, err)
+		assert.EqualValues(t, 2, nullTypes.Int64Val.Int64)
+		assert.Equal(t, "BARACK", nullTypes.StringVal.String)
+	}
+}
+
+func TestRequireWhere(t *testing.T) {
+	for _, conn := range testConnections {
+		sess := conn.NewSession(nil)
+		sess.RequireWhere = true
+
+		id := nextID()
+		_, err := sess.InsertInto("null_types").Columns("id", "int64_val").Values(id, 1).Exec()
+		assert.NoError(t, err)
+
+		_, err = sess.Update("null_types").Set("int64_val", 2).Exec()
+		assert.Equal(t, ErrMissingWhere, err)
+
+		tx, err := sess.Begin()
+		assert.NoError(t, err)
+		_, err = tx.DeleteFrom("null_types").Exec()
+		assert.Equal(t, ErrMissingWhere, err)
+		assert.NoError(t, tx.Rollback())
+
+		var n int64
+		_, err = sess.Select("int64_val").From("null_types").Where(Eq("id", id)).Load(&n)
+		assert.NoError(t, err)
+		assert.EqualValues(t, 1, n)
+
+		_, err = sess.Update("null_types").Set("int64_val", 2).Where(Eq("id", id)).Exec()
+		assert.NoError(t, err)
+		_, err = sess.DeleteFrom("null_types").Where(Eq("id", id)).Exec()
+		assert.NoError(t, err)
+	}
+}
+
+func TestCompound(t *testing.T) {
+	for _, conn := range testConnections {
+		sess := conn.NewSession(nil)
+
+		id1, id2 := nextID(), nextID()
+		_, err := sess.InsertInto("person").Columns("id", "name").Values(id1, "Barack").Values(id2, "Michelle").Exec()
+		assert.NoError(t, err)
+
+		var name []string
+		count, err := sess.Compound(UnionAll(
+			Select("name").From("person").Where(Eq("id", id1)),
+			Select("name").From("person").Where(Eq("id", id2)),
+			Select("name").From("person").Where(Eq("id", id2)),
+		)).OrderDir("name", false).Limit(2).Load(&name)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, count)
+		assert.Equal(t, []string{"Michelle", "Michelle"}, name)
+
+		if conn.Dialect == dialect.MySQL {
+			continue
+		}
+		name = nil
+		_, err = sess.Compound(Except(
+			Select("name").From("person").Where(Eq("id", []int64{id1, id2})),
+			Select("name").From("person").Where(Eq("id", id2)),
+		)).Load(&name)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Barack"}, name)
+	}
+}
+
+func TestWindowFunction(t *testing.T) {
+	for _, conn := range testConnections {
+		if conn.Dialect == dialect.MySQL {
+			// window functions require MySQL 8.0
+			continue
+		}
+		sess := conn.NewSession(nil)
+
+		id1, id2 := nextID(), nextID()
+		_, err := sess.InsertInto("null_types").Columns("id", "int64_val").Values(id1, 10).Values(id2, 20).Exec()
+		assert.NoError(t, err)
+
+		var total []int64
+		_, err = sess.Select(Over("SUM(int64_val)", "w").As("total")).
+			From("null_types").
+			Where(Eq("id", []int64{id1, id2})).
+			Window("w", Window().OrderAsc("id").Rows(UnboundedPreceding, CurrentRow)).
+			OrderDir("id", true).
+			Load(&total)
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{10, 30}, total)
+	}
+}
+
+func TestFunctions(t *testing.T) {
+	for _, conn := range testConnections {
+		sess := conn.NewSession(nil)
+
+		id1, id2 := nextID(), nextID()
+		_, err := sess.InsertInto("null_types").Columns("id", "int64_val").Values(id1, 10).Values(id2, nil).Exec()
+		assert.NoError(t, err)
+
+		var result struct {
+			Count int64 `db:"n"`
+			Sum   int64 `db:"total"`
+			Max   int64 `db:"max_id"`
+		}
+		_, err = sess.Select(
+			Count().As("n"),
+			Sum(Coalesce(I("int64_val"), 5)).As("total"),
+			Max(I("id")).As("max_id"),
+		).From("null_types").Where(Eq("id", []int64{id1, id2})).Load(&result)
+		assert.NoError(t, err)
+		assert.EqualValues(t, 2, result.Count)
+		assert.EqualValues(t, 15, result.Sum)
+		assert.Equal(t, id2, result.Max)
+	}
+}
+
+type TablePerson struct {
+	ID    int64 `db:"id,pk,omitempty"`
+	Name  string
+	Email NullString
+}
+
+func (TablePerson) TableName() string {
+	return "person"
+}
+
+func TestTable(t *testing.T) {
+	table := NewTable(TablePerson{})
+
+	for _, conn := range testConnections {
+		sess := conn.NewSession(nil)
+		tx, err := sess.Begin()
+		assert.NoError(t, err)
+
+		for _, runner := range []SessionRunner{sess, tx} {
+			person := &TablePerson{ID: nextID(), Name: "John Titor"}
+			_, err := table.Insert(runner, person)
+			assert.NoError(t, err)
+
+			exists, err := table.Exists(runner, person.ID)
+			assert.NoError(t, err)
+			assert.True(t, exists)
+
+			person.Name = "John Tailor"
+			_, err = table.Update(runner, person)
+			assert.NoError(t, err)
+
+			var loaded TablePerson
+			err = table.Find(runner, &loaded, person.ID)
+			assert.NoError(t, err)
+			assert.Equal(t, "John Tailor", loaded.Name)
+
+			person.Email = NewNullString("john@example.com")
+			_, err = table.Upsert(runner, person)
+			assert.NoError(t, err)
+
+			var persons []TablePerson
+			count, err := table.FindAll(runner, &persons, Eq("email", "john@example.com"))
+			assert.NoError(t, err)
+			assert.Equal(t, 1, count)
+
+			_, err = table.Delete(runner, person)
+			assert.NoError(t, err)
+
+			err = table.Find(runner, &loaded, person.ID)
+			assert.Equal(t, ErrNotFound, err)
+		}
+		assert.NoError(t, tx.Commit())
+	}
+}
+
+type SoftPerson struct {
+	ID        int64 `db:"id,pk"`
+	Name      string
+	DeletedAt NullTime `db:"deleted_at,deleted_at"`
+}
+
+func TestTableSoftDelete(t *testing.T) {
+	table := NewTable(SoftPerson{})
+
+	for _, conn := range testConnections {
+		sess := conn.NewSession(nil)
+
+		person := &SoftPerson{ID: nextID(), Name: "John Titor"}
+		_, err := table.Insert(sess, person)
+		assert.NoError(t, err)
+
+		result, err := table.Delete(sess, person)
+		assert.NoError(t, err)
+		rowsAffected, err := result.RowsAffected()
+		assert.NoError(t, err)
+		assert.EqualValues(t, 1, rowsAffected)
+
+		var loaded SoftPerson
+		err = table.Find(sess, &loaded, person.ID)
+		assert.Equal(t, ErrNotFound, err)
+
+		exists, err := table.Exists(sess, person.ID)
+		assert.NoError(t, err)
+		assert.False(t, exists)
+
+		err = table.WithDeleted().Find(sess, &loaded, person.ID)
+		assert.NoError(t, err)
+		assert.True(t, loaded.DeletedAt.Valid)
+
+		// soft deleted records are not updated
+		person.Name = "Updated"
+		result, err = table.Update(sess, person)
+		assert.NoError(t, err)
+		rowsAffected, err = result.RowsAffected()
+		assert.NoError(t, err)
+		assert.EqualValues(t, 0, rowsAffected)
+
+		result, err = table.WithDeleted().Update(sess, person)
+		assert.NoError(t, err)
+		rowsAffected, err = result.RowsAffected()
+		assert.NoError(t, err)
+		assert.EqualValues(t, 1, rowsAffected)
+
+		var persons []SoftPerson
+		count, err := table.OnlyDeleted().FindAll(sess, &persons, Eq("id", person.ID))
+		assert.NoError(t, err)
+		assert.Equal(t, 1, count)
+
+		_, err = table.ForceDelete(sess, person)
+		assert.NoError(t, err)
+
+		exists, err = table.WithDeleted().Exists(sess, person.ID)
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	}
+}
+
+func TestStrictMapping(t *testing.T) {
+	for _, conn := range testConnections {
+		sess := conn.NewSession(nil)
+
+		id := nextID()
+		_, err := sess.InsertInto("person").Columns("id", "name", "email").Values(id, "John Titor", "john@example.com").Exec()
+		assert.NoError(t, err)
+
+		// lenient by default
+		var person Person
+		_, err = sess.Select("id", "name").From("person").Where(Eq("id", id)).Load(&person)
+		assert.NoError(t, err)
+
+		_, err = sess.Select("id", "name").From("person").Where(Eq("id", id)).Strict(StrictFields).Load(&person)
+		if assert.IsType(t, &MappingError{}, err) {
+			assert.Equal(t, []string{"email"}, err.(*MappingError).MissingFields)
+			assert.Empty(t, err.(*MappingError).UnmappedColumns)
+		}
+
+		type personName struct {
+			Name string
+		}
+		var name personName
+		_, err = sess.Select("id", "name").From("person").Where(Eq("id", id)).Strict(StrictColumns).Load(&name)
+		if assert.IsType(t, &MappingError{}, err) {
+			assert.Equal(t, []string{"id"}, err.(*MappingError).UnmappedColumns)
+		}
+
+		_, err = sess.Select("*").From("person").Where(Eq("id", id)).Strict(StrictAll).Load(&person)
+		assert.NoError(t, err)
+	}
+}
+
 func TestContextCancel(t *testing.T) {
 
 	for _, conn := range testConnections {
@@ -0,0 +1,128 @@
+package fjord
+
+// FuncExpr is a function call, which can be aliased with As
+type FuncExpr interface {
+	Builder
+	As(string) Builder
+}
+
+// function builds a function call `name(arg, ...)`.
+// An argument is an identifier with I, a nested Builder, or a value.
+type function struct {
+	name     string
+	arg      []interface{}
+	distinct bool
+	// maxArg limits the number of arguments when it is positive
+	maxArg int
+}
+
+// Func creates a call of function name with arguments.
+// Use I for identifiers; other arguments except Builder are bound as values.
+//
+//	fjord.Func("CONCAT", fjord.I("first_name"), " ", fjord.I("last_name")).As("name")
+func Func(name string, arg ...interface{}) FuncExpr {
+	return &function{name: name, arg: arg}
+}
+
+// Count creates `COUNT(expr)`, or `COUNT(*)` without expr.
+// More than one expr is an error on Build.
+func Count(expr ...interface{}) FuncExpr {
+	if len(expr) == 0 {
+		expr = []interface{}{Expr("*")}
+	}
+	return &function{name: "COUNT", arg: expr, maxArg: 1}
+}
+
+// CountDistinct creates `COUNT(DISTINCT expr)`
+func CountDistinct(expr interface{}) FuncExpr {
+	return &function{name: "COUNT", arg: []interface{}{column(expr)}, distinct: true}
+}
+
+// Sum creates `SUM(expr)`
+func Sum(expr interface{}) FuncExpr {
+	return Func("SUM", column(expr))
+}
+
+// Avg creates `AVG(expr)`
+func Avg(expr interface{}) FuncExpr {
+	return Func("AVG", column(expr))
+}
+
+// Min creates `MIN(expr)`
+func Min(expr interface{}) FuncExpr {
+	return Func("MIN", column(expr))
+}
+
+// Max creates `MAX(expr)`
+func Max(expr interface{}) FuncExpr {
+	return Func("MAX", column(expr))
+}
+
+// Coalesce creates `COALESCE(expr, ...)`
+func Coalesce(expr ...interface{}) FuncExpr {
+	return Func("COALESCE", expr...)
+}
+
+// Lower creates `LOWER(expr)`
+func Lower(expr interface{}) FuncExpr {
+	return Func("LOWER", column(expr))
+}
+
+// column takes a string argument of a single column function as an identifier,
+// e.g. Sum("amount") sums the column amount, not the string 'amount'
+func column(expr interface{}) interface{} {
+	if s, ok := expr.(string); ok {
+		return I(s)
+	}
+	return expr
+}
+
+func (f *function) Build(d Dialect, buf Buffer) error {
+	if f.maxArg > 0 && len(f.arg) > f.maxArg {
+		return ErrArgumentCount
+	}
+	buf.WriteString(f.name)
+	buf.WriteString("(")
+	if f.distinct {
+		buf.WriteString("DISTINCT ")
+	}
+	for i, arg := range f.arg {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(placeholder)
+		buf.WriteValue(arg)
+	}
+	buf.WriteString(")")
+	return nil
+}
+
+func (f *function) As(alias string) Builder {
+	return as(f, alias)
+}
+
+type cast struct {
+	expr     interface{}
+	dataType string
+}
+
+// Cast creates `CAST(expr AS dataType)`.
+// dataType is written as is, so it must be valid in the dialect, e.g. SIGNED on MySQL
+// and BIGINT on PostgreSQL.
+func Cast(expr interface{}, dataType string) FuncExpr {
+	return &cast{expr: expr, dataType: dataType}
+}
+
+func (c *cast) Build(d Dialect, buf Buffer) error {
+	buf.WriteString("CAST(")
+	buf.WriteString(placeholder)
+	buf.WriteValue(c.expr)
+	buf.WriteString(" AS ")
+	buf.WriteString(c.dataType)
+	buf.WriteString(")")
+	return nil
+}
+
+func (c *cast) As(alias string) Builder {
+	return as(c, alias)
+}
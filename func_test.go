@@ -0,0 +1,139 @@
+package fjord
+
+import (
+	"testing"
+
+	"github.com/iktakahiro/fjord/dialect"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFunc(t *testing.T) {
+	for _, test := range []struct {
+		d     Dialect
+		expr  Builder
+		query string
+	}{
+		{
+			d:     dialect.MySQL,
+			expr:  Count(),
+			query: "COUNT(*)",
+		},
+		{
+			d:     dialect.MySQL,
+			expr:  Count(I("id")).As("n"),
+			query: "COUNT(`id`) AS `n`",
+		},
+		{
+			d:     dialect.PostgreSQL,
+			expr:  CountDistinct(I("p.name")),
+			query: `COUNT(DISTINCT "p"."name")`,
+		},
+		{
+			d:     dialect.PostgreSQL,
+			expr:  Sum(I("amount")).As("total"),
+			query: `SUM("amount") AS "total"`,
+		},
+		{
+			d:     dialect.MySQL,
+			expr:  Sum("amount"),
+			query: "SUM(`amount`)",
+		},
+		{
+			d:     dialect.PostgreSQL,
+			expr:  CountDistinct("p.name"),
+			query: `COUNT(DISTINCT "p"."name")`,
+		},
+		{
+			d:     dialect.MySQL,
+			expr:  Lower("email"),
+			query: "LOWER(`email`)",
+		},
+		{
+			d:     dialect.MySQL,
+			expr:  Func("CONCAT", "a", "b"),
+			query: "CONCAT('a', 'b')",
+		},
+		{
+			d:     dialect.MySQL,
+			expr:  Avg(I("score")),
+			query: "AVG(`score`)",
+		},
+		{
+			d:     dialect.MySQL,
+			expr:  Min(I("a")),
+			query: "MIN(`a`)",
+		},
+		{
+			d:     dialect.MySQL,
+			expr:  Max(Expr("a + b")),
+			query: "MAX(a + b)",
+		},
+		{
+			d:     dialect.MySQL,
+			expr:  Coalesce(I("nickname"), I("name"), "anonymous"),
+			query: "COALESCE(`nickname`, `name`, 'anonymous')",
+		},
+		{
+			d:     dialect.PostgreSQL,
+			expr:  Lower(I("email")).As("email"),
+			query: `LOWER("email") AS "email"`,
+		},
+		{
+			d:     dialect.MySQL,
+			expr:  Cast(I("price"), "SIGNED"),
+			query: "CAST(`price` AS SIGNED)",
+		},
+		{
+			d:     dialect.PostgreSQL,
+			expr:  Cast("1", "BIGINT").As("n"),
+			query: `CAST('1' AS BIGINT) AS "n"`,
+		},
+		{
+			d:     dialect.MySQL,
+			expr:  Func("CONCAT", I("first_name"), " ", I("last_name")).As("name"),
+			query: "CONCAT(`first_name`, ' ', `last_name`) AS `name`",
+		},
+		{
+			d:     dialect.MySQL,
+			expr:  Func("NOW"),
+			query: "NOW()",
+		},
+		{
+			d:     dialect.PostgreSQL,
+			expr:  Coalesce(Sum(I("amount")), 0),
+			query: `COALESCE(SUM("amount"), 0)`,
+		},
+		{
+			d:     dialect.PostgreSQL,
+			expr:  Func("GREATEST", I("a"), Select("max(b)").From("t")),
+			query: `GREATEST("a", (SELECT max(b) FROM t))`,
+		},
+		{
+			d:     dialect.PostgreSQL,
+			expr:  Over(Sum(I("amount")), Window().OrderAsc("day")).As("total"),
+			query: `SUM("amount") OVER (ORDER BY day ASC) AS "total"`,
+		},
+	} {
+		buf := NewBuffer()
+		err := test.expr.Build(test.d, buf)
+		assert.NoError(t, err)
+		query, err := InterpolateForDialect(buf.String(), buf.Value(), test.d)
+		assert.NoError(t, err)
+		assert.Equal(t, test.query, query)
+	}
+}
+
+func TestCountArgumentCount(t *testing.T) {
+	err := Count(I("a"), I("b")).Build(dialect.PostgreSQL, NewBuffer())
+	assert.Equal(t, ErrArgumentCount, err)
+}
+
+func TestFuncSelectColumn(t *testing.T) {
+	stmt := Select(I("dept"), Count().As("n"), Avg(I("salary"))).From("employee").GroupBy("dept")
+	buf := NewBuffer()
+	err := stmt.Build(dialect.MySQL, buf)
+	assert.NoError(t, err)
+	query, err := InterpolateForDialect(buf.String(), buf.Value(), dialect.MySQL)
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT `dept` AS dept, COUNT(*) AS `n`, AVG(`salary`) FROM employee GROUP BY dept", query)
+}
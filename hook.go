@@ -0,0 +1,110 @@
+package fjord
+
+import "reflect"
+
+// BeforeInserter is implemented by a record to run code before it is inserted
+// with InsertBuilder.Record. When it returns an error, the statement is not executed.
+type BeforeInserter interface {
+	BeforeInsert(runner SessionRunner) error
+}
+
+// AfterInserter is implemented by a record to run code after it is inserted
+// with InsertBuilder.Record.
+type AfterInserter interface {
+	AfterInsert(runner SessionRunner) error
+}
+
+// BeforeUpdater is implemented by a record to run code before it is updated
+// with UpdateBuilder.SetRecord. When it returns an error, the statement is not executed.
+type BeforeUpdater interface {
+	BeforeUpdate(runner SessionRunner) error
+}
+
+// AfterLoader is implemented by a record to run code after it is loaded.
+type AfterLoader interface {
+	AfterLoad(runner SessionRunner) error
+}
+
+// hookRunner returns the runner of a builder as SessionRunner
+func hookRunner(r runner) (SessionRunner, bool) {
+	sr, ok := r.(SessionRunner)
+	return sr, ok
+}
+
+func beforeInsert(r runner, value interface{}) error {
+	sr, ok := hookRunner(r)
+	if !ok {
+		return nil
+	}
+	if hook, ok := value.(BeforeInserter); ok {
+		return hook.BeforeInsert(sr)
+	}
+	return nil
+}
+
+func afterInsert(r runner, value interface{}) error {
+	sr, ok := hookRunner(r)
+	if !ok {
+		return nil
+	}
+	if hook, ok := value.(AfterInserter); ok {
+		return hook.AfterInsert(sr)
+	}
+	return nil
+}
+
+func beforeUpdate(r runner, value interface{}) error {
+	sr, ok := hookRunner(r)
+	if !ok {
+		return nil
+	}
+	if hook, ok := value.(BeforeUpdater); ok {
+		return hook.BeforeUpdate(sr)
+	}
+	return nil
+}
+
+// afterLoad calls AfterLoad of loaded records.
+// For a slice, only elements from index start are called.
+func afterLoad(r runner, value interface{}, start int) error {
+	sr, ok := hookRunner(r)
+	if !ok {
+		return nil
+	}
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return nil
+	}
+	v = v.Elem()
+	if v.Kind() != reflect.Slice {
+		return callAfterLoad(sr, v)
+	}
+	for i := start; i < v.Len(); i++ {
+		if err := callAfterLoad(sr, v.Index(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadedLen returns the length of a slice before loading
+func loadedLen(value interface{}) int {
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Slice {
+		return 0
+	}
+	return v.Elem().Len()
+}
+
+func callAfterLoad(sr SessionRunner, v reflect.Value) error {
+	if v.Kind() != reflect.Ptr && v.CanAddr() {
+		v = v.Addr()
+	}
+	if v.Kind() == reflect.Ptr && v.IsNil() {
+		return nil
+	}
+	if hook, ok := v.Interface().(AfterLoader); ok {
+		return hook.AfterLoad(sr)
+	}
+	return nil
+}
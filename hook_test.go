@@ -0,0 +1,73 @@
+package fjord
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/iktakahiro/fjord/dialect"
+	"github.com/stretchr/testify/assert"
+)
+
+var errInvalidHookRecord = errors.New("invalid record")
+
+type hookRecord struct {
+	ID     int64  `db:"id"`
+	Name   string `db:"name"`
+	Loaded bool   `db:"-"`
+}
+
+func (r *hookRecord) BeforeInsert(runner SessionRunner) error {
+	if r.Name == "" {
+		return errInvalidHookRecord
+	}
+	return nil
+}
+
+func (r *hookRecord) BeforeUpdate(runner SessionRunner) error {
+	return r.BeforeInsert(runner)
+}
+
+func (r *hookRecord) AfterLoad(runner SessionRunner) error {
+	r.Loaded = true
+	return nil
+}
+
+func TestBeforeHookAbort(t *testing.T) {
+	sess := (&Connection{Dialect: dialect.MySQL, EventReceiver: nullReceiver}).NewSession(nil)
+
+	_, err := sess.InsertInto("hook").Columns("id", "name").Record(&hookRecord{ID: 1}).Exec()
+	assert.Equal(t, errInvalidHookRecord, err)
+
+	_, err = sess.Update("hook").SetRecord(&hookRecord{ID: 1}).Where(Eq("id", 1)).Exec()
+	assert.Equal(t, errInvalidHookRecord, err)
+}
+
+func TestInsertErrorEvent(t *testing.T) {
+	log := &errorEventRecorder{}
+	sess := (&Connection{Dialect: dialect.MySQL, EventReceiver: log}).NewSession(nil)
+
+	_, err := sess.InsertInto("hook").Columns("id", "name").Record(&hookRecord{ID: 1}).Exec()
+	assert.Equal(t, errInvalidHookRecord, err)
+
+	_, err = sess.InsertInto("hook").Columns("id", "name").Records(1).Exec()
+	assert.Equal(t, ErrInvalidRecords, err)
+
+	assert.Equal(t, []string{"fjord.insert.hook", "fjord.insert.record"}, log.event)
+}
+
+func TestAfterLoad(t *testing.T) {
+	sess := (&Connection{Dialect: dialect.MySQL, EventReceiver: nullReceiver}).NewSession(nil)
+
+	records := []hookRecord{{ID: 1}, {ID: 2}}
+	assert.NoError(t, afterLoad(sess, &records, 1))
+	assert.False(t, records[0].Loaded)
+	assert.True(t, records[1].Loaded)
+
+	ptrs := []*hookRecord{{ID: 1}, nil}
+	assert.NoError(t, afterLoad(sess, &ptrs, 0))
+	assert.True(t, ptrs[0].Loaded)
+
+	var record hookRecord
+	assert.NoError(t, afterLoad(sess, &record, 0))
+	assert.True(t, record.Loaded)
+}
@@ -14,11 +14,19 @@ func (i I) As(alias string) Builder {
 }
 
 func as(expr interface{}, alias string) Builder {
-	return BuildFunc(func(d Dialect, buf Buffer) error {
-		buf.WriteString(placeholder)
-		buf.WriteValue(expr)
-		buf.WriteString(" AS ")
-		buf.WriteString(d.QuoteIdent(alias))
-		return nil
-	})
+	return &aliased{expr: expr, alias: alias}
+}
+
+// aliased is an expression with an alias, e.g. `a1` AS `a2`
+type aliased struct {
+	expr  interface{}
+	alias string
+}
+
+func (a *aliased) Build(d Dialect, buf Buffer) error {
+	buf.WriteString(placeholder)
+	buf.WriteValue(a.expr)
+	buf.WriteString(" AS ")
+	buf.WriteString(d.QuoteIdent(a.alias))
+	return nil
 }
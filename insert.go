@@ -3,8 +3,16 @@ package fjord
 import (
 	"bytes"
 	"reflect"
+	"time"
+	"unicode"
+
+	"github.com/iktakahiro/fjord/dialect"
 )
 
+// Default is written as `DEFAULT` in a value tuple, so that the column
+// is set to its default value. e.g. Values(fjord.Default, "title")
+var Default = Expr("DEFAULT")
+
 // InsertStmt builds `INSERT INTO ...`
 type InsertStmt struct {
 	raw
@@ -12,6 +20,22 @@ type InsertStmt struct {
 	Table  string
 	Column []string
 	Value  [][]interface{}
+	// Select is inserted instead of Value. e.g. `INSERT INTO ... SELECT ...`
+	Select *SelectStmt
+
+	ConflictColumn []string
+	UpdateColumn   []string
+	// IsIgnore ignores rows which conflict with existing rows
+	IsIgnore bool
+	// IsDefaultValues inserts a row of default values without columns
+	IsDefaultValues bool
+
+	// Clock returns the time for fields tagged with autocreate or autoupdate.
+	// When it is nil, NOW() of the database is used.
+	Clock func() time.Time
+
+	// returning is the column returned by PostgreSQL
+	returning string
 }
 
 // Build builds `INSERT INTO ...` in dialect
@@ -24,12 +48,25 @@ func (b *InsertStmt) Build(d Dialect, buf Buffer) error {
 		return ErrTableNotSpecified
 	}
 
+	if b.IsIgnore && len(b.ConflictColumn) > 0 {
+		return ErrNotSupported
+	}
+
+	if b.Select != nil {
+		return b.buildSelect(d, buf)
+	}
+
+	if b.IsDefaultValues {
+		return b.buildDefaultValues(d, buf)
+	}
+
 	if len(b.Column) == 0 {
 		return ErrColumnNotSpecified
 	}
 
-	buf.WriteString("INSERT INTO ")
-	buf.WriteString(d.QuoteIdent(b.Table))
+	if err := b.buildInsertInto(d, buf); err != nil {
+		return err
+	}
 
 	placeholderBuf := new(bytes.Buffer)
 	placeholderBuf.WriteString("(")
@@ -55,6 +92,170 @@ func (b *InsertStmt) Build(d Dialect, buf Buffer) error {
 		buf.WriteValue(tuple...)
 	}
 
+	return b.buildSuffix(d, buf)
+}
+
+// buildSelect builds `INSERT INTO table (column) SELECT ...`
+func (b *InsertStmt) buildSelect(d Dialect, buf Buffer) error {
+	if len(b.Value) > 0 {
+		return ErrInsertSource
+	}
+	if n, ok := selectColumnCount(b.Select); ok && len(b.Column) > 0 && n != len(b.Column) {
+		return ErrColumnCount
+	}
+
+	if err := b.buildInsertInto(d, buf); err != nil {
+		return err
+	}
+	if len(b.Column) > 0 {
+		buf.WriteString(" (")
+		for i, col := range b.Column {
+			if i > 0 {
+				buf.WriteString(",")
+			}
+			buf.WriteString(d.QuoteIdent(col))
+		}
+		buf.WriteString(")")
+	}
+	buf.WriteString(" ")
+	buf.WriteString(placeholder)
+	buf.WriteValue(b.Select)
+
+	return b.buildSuffix(d, buf)
+}
+
+// buildInsertInto builds `INSERT INTO table`, or `INSERT IGNORE INTO table` in MySQL
+func (b *InsertStmt) buildInsertInto(d Dialect, buf Buffer) error {
+	buf.WriteString("INSERT ")
+	if b.IsIgnore {
+		switch d {
+		case dialect.MySQL:
+			buf.WriteString("IGNORE ")
+		case dialect.PostgreSQL:
+			// written as `ON CONFLICT DO NOTHING`
+		default:
+			return ErrNotSupported
+		}
+	}
+	buf.WriteString("INTO ")
+	buf.WriteString(d.QuoteIdent(b.Table))
+	return nil
+}
+
+// buildDefaultValues builds `INSERT INTO table DEFAULT VALUES` in PostgreSQL,
+// or `INSERT INTO table () VALUES ()` in MySQL
+func (b *InsertStmt) buildDefaultValues(d Dialect, buf Buffer) error {
+	if len(b.Column) > 0 || len(b.Value) > 0 {
+		return ErrInsertSource
+	}
+	if err := b.buildInsertInto(d, buf); err != nil {
+		return err
+	}
+	switch d {
+	case dialect.MySQL:
+		buf.WriteString(" () VALUES ()")
+	default:
+		buf.WriteString(" DEFAULT VALUES")
+	}
+	return b.buildSuffix(d, buf)
+}
+
+// buildSuffix builds clauses after values
+func (b *InsertStmt) buildSuffix(d Dialect, buf Buffer) error {
+	if len(b.ConflictColumn) > 0 {
+		if err := b.buildOnConflict(d, buf); err != nil {
+			return err
+		}
+	}
+	if b.IsIgnore && d == dialect.PostgreSQL {
+		buf.WriteString(" ON CONFLICT DO NOTHING")
+	}
+	if b.returning != "" {
+		buf.WriteString(" RETURNING ")
+		buf.WriteString(d.QuoteIdent(b.returning))
+	}
+	return nil
+}
+
+// selectColumnCount returns the number of columns of a select statement.
+// It returns false unless every column is a single identifier or an aliased expression,
+// because `*`, `a, b` or Expr may stand for any number of columns.
+func selectColumnCount(stmt *SelectStmt) (int, bool) {
+	if stmt.raw.Query != "" {
+		return 0, false
+	}
+	for _, col := range stmt.Column {
+		switch col := col.(type) {
+		case string:
+			if !isIdentifier(col) {
+				return 0, false
+			}
+		case I, *aliased:
+		default:
+			return 0, false
+		}
+	}
+	return len(stmt.Column), true
+}
+
+// isIdentifier reports whether s is a single column name, e.g. `id` or `person.id`
+func isIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r != '_' && r != '.' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// buildOnConflict builds `ON DUPLICATE KEY UPDATE ...` in MySQL,
+// or `ON CONFLICT (...) DO UPDATE SET ...` in PostgreSQL
+func (b *InsertStmt) buildOnConflict(d Dialect, buf Buffer) error {
+	switch d {
+	case dialect.MySQL:
+		buf.WriteString(" ON DUPLICATE KEY UPDATE ")
+		column := b.UpdateColumn
+		if len(column) == 0 {
+			// nothing to update, but a conflict should not be an error
+			column = b.ConflictColumn[:1]
+		}
+		for i, col := range column {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			buf.WriteString(d.QuoteIdent(col))
+			buf.WriteString(" = VALUES(")
+			buf.WriteString(d.QuoteIdent(col))
+			buf.WriteString(")")
+		}
+	case dialect.PostgreSQL:
+		buf.WriteString(" ON CONFLICT (")
+		for i, col := range b.ConflictColumn {
+			if i > 0 {
+				buf.WriteString(",")
+			}
+			buf.WriteString(d.QuoteIdent(col))
+		}
+		buf.WriteString(") DO ")
+		if len(b.UpdateColumn) == 0 {
+			buf.WriteString("NOTHING")
+			return nil
+		}
+		buf.WriteString("UPDATE SET ")
+		for i, col := range b.UpdateColumn {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			buf.WriteString(d.QuoteIdent(col))
+			buf.WriteString(" = EXCLUDED.")
+			buf.WriteString(d.QuoteIdent(col))
+		}
+	default:
+		return ErrNotSupported
+	}
 	return nil
 }
 
@@ -87,17 +288,78 @@ func (b *InsertStmt) Values(value ...interface{}) *InsertStmt {
 	return b
 }
 
-// Record adds a tuple for columns from a struct
+// Ignore ignores rows which conflict with existing rows.
+// It is `INSERT IGNORE` in MySQL, and `ON CONFLICT DO NOTHING` in PostgreSQL.
+func (b *InsertStmt) Ignore() *InsertStmt {
+	b.IsIgnore = true
+	return b
+}
+
+// DefaultValues inserts a row in which all columns have default values.
+func (b *InsertStmt) DefaultValues() *InsertStmt {
+	b.IsDefaultValues = true
+	return b
+}
+
+// FromSelect inserts rows selected by a select statement instead of values.
+// When both of the insert and the select list columns, their numbers must match.
+func (b *InsertStmt) FromSelect(stmt *SelectStmt) *InsertStmt {
+	b.Select = stmt
+	return b
+}
+
+// OnConflictUpdate updates columns with inserting values when a row conflicts
+// on key columns. MySQL uses a unique key of the table instead of key columns.
+func (b *InsertStmt) OnConflictUpdate(key []string, column ...string) *InsertStmt {
+	b.ConflictColumn = key
+	b.UpdateColumn = column
+	return b
+}
+
+// Records adds elements of a slice of structs or pointers to structs.
+// Field metadata is computed once per struct type. Nil elements are skipped.
+func (b *InsertStmt) Records(slice interface{}) *InsertStmt {
+	v := reflect.Indirect(reflect.ValueOf(slice))
+	if v.Kind() != reflect.Slice {
+		return b
+	}
+	t := indirectType(v.Type().Elem())
+	if t.Kind() != reflect.Struct {
+		return b
+	}
+
+	m := typeFields(t)
+	for i := 0; i < v.Len(); i++ {
+		elem := reflect.Indirect(v.Index(i))
+		if !elem.IsValid() {
+			continue
+		}
+		value := make([]interface{}, len(b.Column))
+		for j, key := range b.Column {
+			if index, ok := m[key]; ok {
+				if field, ok := index.value(elem); ok {
+					value[j] = field.insertValue(b.Clock)
+				}
+			}
+		}
+		b.Values(value...)
+	}
+	return b
+}
+
+// Record adds a tuple for columns from a struct.
+// `DEFAULT` is written for fields tagged with readonly or -insert,
+// and for empty fields tagged with omitempty.
+// The current time is written for fields tagged with autocreate or autoupdate.
 func (b *InsertStmt) Record(structValue interface{}) *InsertStmt {
 	v := reflect.Indirect(reflect.ValueOf(structValue))
 
 	if v.Kind() == reflect.Struct {
 		var value []interface{}
-		m := structMap(v, true)
+		m := structFieldMap(v, true)
 		for _, key := range b.Column {
-
-			if val, ok := m[key]; ok {
-				value = append(value, val.Interface())
+			if field, ok := m[key]; ok {
+				value = append(value, field.insertValue(b.Clock))
 			} else {
 				value = append(value, nil)
 			}
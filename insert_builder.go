@@ -2,7 +2,10 @@ package fjord
 
 import (
 	"database/sql"
+	"database/sql/driver"
 	"reflect"
+
+	"github.com/iktakahiro/fjord/dialect"
 )
 
 type InsertBuilder struct {
@@ -13,23 +16,40 @@ type InsertBuilder struct {
 	RecordID reflect.Value
 
 	*InsertStmt
+
+	// MaxBatchRows is the max number of rows in one statement. 0 means no limit.
+	MaxBatchRows int
+	// MaxBatchParams is the max number of parameters in one statement. 0 means no limit.
+	MaxBatchParams int
+	// BatchTx runs batches in a transaction when the runner is a *Session.
+	BatchTx bool
+
+	record []interface{}
+	// rowID is the ID field of each row added by Records
+	rowID    []reflect.Value
+	idColumn string
+	err      error
 }
 
 func (sess *Session) InsertInto(table string) *InsertBuilder {
+	stmt := InsertInto(table)
+	stmt.Clock = sess.Clock
 	return &InsertBuilder{
 		runner:        sess,
 		EventReceiver: sess,
 		Dialect:       sess.Dialect,
-		InsertStmt:    InsertInto(table),
+		InsertStmt:    stmt,
 	}
 }
 
 func (tx *Tx) InsertInto(table string) *InsertBuilder {
+	stmt := InsertInto(table)
+	stmt.Clock = tx.Clock
 	return &InsertBuilder{
 		runner:        tx,
 		EventReceiver: tx,
 		Dialect:       tx.Dialect,
-		InsertStmt:    InsertInto(table),
+		InsertStmt:    stmt,
 	}
 }
 
@@ -65,34 +85,223 @@ func (b *InsertBuilder) Pair(column string, value interface{}) *InsertBuilder {
 }
 
 func (b *InsertBuilder) Exec() (sql.Result, error) {
-	result, err := exec(b.runner, b.EventReceiver, b, b.Dialect)
-	if err != nil {
-		return nil, err
+	if b.err != nil {
+		event := "fjord.insert.hook"
+		if b.err == ErrInvalidRecords {
+			event = "fjord.insert.record"
+		}
+		return nil, b.EventErrKv(event, b.err, kvs{
+			"table": b.Table,
+		})
+	}
+
+	var result sql.Result
+	var err error
+	if size := b.batchSize(); size > 0 && b.raw.Query == "" && len(b.Value) > size {
+		result, err = b.execBatch(size)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		result, err = b.execStmt(b.runner, b.InsertStmt, b.backfillID())
+		if err != nil {
+			return nil, err
+		}
+
+		if b.RecordID.IsValid() {
+			if id, err := result.LastInsertId(); err == nil {
+				b.RecordID.SetInt(id)
+			}
+		}
 	}
 
-	if b.RecordID.IsValid() {
-		if id, err := result.LastInsertId(); err == nil {
-			b.RecordID.SetInt(id)
+	for _, record := range b.record {
+		if err := afterInsert(b.runner, record); err != nil {
+			return result, b.EventErrKv("fjord.insert.hook", err, kvs{
+				"table": b.Table,
+			})
 		}
 	}
 
 	return result, nil
 }
 
+// batchSize returns the number of rows in one statement, or 0 for no limit.
+func (b *InsertBuilder) batchSize() int {
+	size := b.MaxBatchRows
+	if b.MaxBatchParams > 0 && len(b.Column) > 0 {
+		n := b.MaxBatchParams / len(b.Column)
+		if n < 1 {
+			n = 1
+		}
+		if size <= 0 || n < size {
+			size = n
+		}
+	}
+	return size
+}
+
+// batchResult is the result of batched statements.
+// LastInsertId is from the last statement.
+type batchResult struct {
+	sql.Result
+	rowsAffected int64
+}
+
+func (r batchResult) RowsAffected() (int64, error) {
+	return r.rowsAffected, nil
+}
+
+// execBatch executes the insert split into statements of size rows.
+func (b *InsertBuilder) execBatch(size int) (sql.Result, error) {
+	runner := b.runner
+	var tx *Tx
+	if sess, ok := b.runner.(*Session); ok && b.BatchTx {
+		var err error
+		tx, err = sess.Begin()
+		if err != nil {
+			return nil, err
+		}
+		defer tx.RollbackUnlessCommitted()
+		runner = tx
+	}
+
+	id := b.backfillID()
+	var total batchResult
+	for start := 0; start < len(b.Value); start += size {
+		end := start + size
+		if end > len(b.Value) {
+			end = len(b.Value)
+		}
+		stmt := *b.InsertStmt
+		stmt.Value = b.Value[start:end]
+
+		var batchID []reflect.Value
+		if id != nil {
+			batchID = id[start:end]
+		}
+		result, err := b.execStmt(runner, &stmt, batchID)
+		if err != nil {
+			return nil, err
+		}
+		n, err := result.RowsAffected()
+		if err != nil {
+			return nil, err
+		}
+		total.Result = result
+		total.rowsAffected += n
+	}
+
+	if tx != nil {
+		if err := tx.Commit(); err != nil {
+			return nil, err
+		}
+	}
+	return total, nil
+}
+
+// backfillID returns ID fields of rows when all rows are added by Records.
+// Rows are not back-filled with Ignore or OnConflictUpdate, because IDs cannot
+// be matched to rows by position when some rows are skipped or updated.
+func (b *InsertBuilder) backfillID() []reflect.Value {
+	if b.idColumn == "" || len(b.rowID) != len(b.Value) {
+		return nil
+	}
+	if b.IsIgnore || len(b.ConflictColumn) > 0 || len(b.UpdateColumn) > 0 {
+		return nil
+	}
+	return b.rowID
+}
+
+// execStmt executes an insert statement, and sets generated IDs to id.
+// PostgreSQL returns IDs with `RETURNING`. On MySQL, IDs are assumed to be
+// consecutive from LastInsertId, so they are set only when all IDs are generated.
+// The assumption does not hold with innodb_autoinc_lock_mode=2 or
+// auto_increment_increment > 1, where back-filled IDs may be wrong.
+func (b *InsertBuilder) execStmt(runner runner, stmt *InsertStmt, id []reflect.Value) (sql.Result, error) {
+	if len(id) == 0 {
+		return exec(runner, b.EventReceiver, stmt, b.Dialect)
+	}
+
+	switch b.Dialect {
+	case dialect.PostgreSQL:
+		returning := *stmt
+		returning.returning = b.idColumn
+		var value []int64
+		count, err := query(runner, b.EventReceiver, &returning, b.Dialect, &value, 0)
+		if err != nil {
+			return nil, err
+		}
+		for i := range value {
+			if i < len(id) && id[i].IsValid() {
+				id[i].SetInt(value[i])
+			}
+		}
+		return driver.RowsAffected(count), nil
+	case dialect.MySQL:
+		result, err := exec(runner, b.EventReceiver, stmt, b.Dialect)
+		if err != nil {
+			return nil, err
+		}
+		for _, field := range id {
+			if !field.IsValid() || field.Int() != 0 {
+				return result, nil
+			}
+		}
+		n, err := result.RowsAffected()
+		if err != nil || n != int64(len(id)) {
+			return result, nil
+		}
+		first, err := result.LastInsertId()
+		if err != nil {
+			return result, nil
+		}
+		for i, field := range id {
+			field.SetInt(first + int64(i))
+		}
+		return result, nil
+	}
+	return exec(runner, b.EventReceiver, stmt, b.Dialect)
+}
+
+// BatchRows splits the insert into statements of at most n rows.
+func (b *InsertBuilder) BatchRows(n int) *InsertBuilder {
+	b.MaxBatchRows = n
+	return b
+}
+
+// BatchParams splits the insert into statements of at most n parameters.
+// e.g. PostgreSQL allows 65535 parameters in one statement.
+func (b *InsertBuilder) BatchParams(n int) *InsertBuilder {
+	b.MaxBatchParams = n
+	return b
+}
+
+// InTransaction runs batches in a transaction, so that all or none of rows are inserted.
+// It has no effect when the builder is created by a *Tx.
+func (b *InsertBuilder) InTransaction() *InsertBuilder {
+	b.BatchTx = true
+	return b
+}
+
 func (b *InsertBuilder) Columns(column ...string) *InsertBuilder {
 	b.InsertStmt.Columns(column...)
 	return b
 }
 
+// Record adds a struct to insert.
+// BeforeInsert of the struct is called here, and AfterInsert is called by Exec.
 func (b *InsertBuilder) Record(structValue interface{}) *InsertBuilder {
+	if err := beforeInsert(b.runner, structValue); err != nil && b.err == nil {
+		b.err = err
+	}
+	b.record = append(b.record, structValue)
+
 	v := reflect.Indirect(reflect.ValueOf(structValue))
 	if v.Kind() == reflect.Struct && v.CanSet() {
-		// ID is recommended by golint here
-		for _, name := range []string{"Id", "ID"} {
-			field := v.FieldByName(name)
-			if field.IsValid() && field.Kind() == reflect.Int64 {
-				b.RecordID = field
-				break
+		if idField, ok := recordIDField(v.Type()); ok {
+			if field, ok := (fieldIndex{index: idField.Index}).value(v); ok {
+				b.RecordID = field.value
 			}
 		}
 	}
@@ -101,6 +310,85 @@ func (b *InsertBuilder) Record(structValue interface{}) *InsertBuilder {
 	return b
 }
 
+// Records adds elements of a slice of structs or pointers to structs.
+// Generated IDs are set to Id or ID int64 fields of the elements when all rows
+// are added by Records. Nil elements are skipped.
+//
+// On MySQL, IDs are computed as consecutive values from LastInsertId, which is
+// wrong with innodb_autoinc_lock_mode=2 (interleaved) or auto_increment_increment > 1.
+// Do not rely on back-filled IDs with such a server.
+func (b *InsertBuilder) Records(slice interface{}) *InsertBuilder {
+	v := reflect.Indirect(reflect.ValueOf(slice))
+	if v.Kind() != reflect.Slice || indirectType(v.Type().Elem()).Kind() != reflect.Struct {
+		if b.err == nil {
+			b.err = ErrInvalidRecords
+		}
+		return b
+	}
+
+	idField, hasID := recordIDField(indirectType(v.Type().Elem()))
+	if hasID && b.idColumn == "" {
+		b.idColumn = getColumnNameFromTag(idField, true)
+	}
+	for i := 0; i < v.Len(); i++ {
+		elem := v.Index(i)
+		if elem.Kind() != reflect.Ptr {
+			elem = elem.Addr()
+		}
+		if elem.IsNil() {
+			continue
+		}
+		record := elem.Interface()
+		if err := beforeInsert(b.runner, record); err != nil && b.err == nil {
+			b.err = err
+		}
+		b.record = append(b.record, record)
+
+		var id reflect.Value
+		if hasID {
+			if field, ok := (fieldIndex{index: idField.Index}).value(elem.Elem()); ok {
+				id = field.value
+			}
+		}
+		b.rowID = append(b.rowID, id)
+	}
+
+	b.InsertStmt.Records(slice)
+	return b
+}
+
+// recordIDField finds an Id or ID int64 field of a struct type
+func recordIDField(t reflect.Type) (reflect.StructField, bool) {
+	// ID is recommended by golint here
+	for _, name := range []string{"Id", "ID"} {
+		field, ok := t.FieldByName(name)
+		if ok && field.Type.Kind() == reflect.Int64 {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+func (b *InsertBuilder) Ignore() *InsertBuilder {
+	b.InsertStmt.Ignore()
+	return b
+}
+
+func (b *InsertBuilder) DefaultValues() *InsertBuilder {
+	b.InsertStmt.DefaultValues()
+	return b
+}
+
+func (b *InsertBuilder) FromSelect(stmt *SelectStmt) *InsertBuilder {
+	b.InsertStmt.FromSelect(stmt)
+	return b
+}
+
+func (b *InsertBuilder) OnConflictUpdate(key []string, column ...string) *InsertBuilder {
+	b.InsertStmt.OnConflictUpdate(key, column...)
+	return b
+}
+
 func (b *InsertBuilder) Values(value ...interface{}) *InsertBuilder {
 	b.InsertStmt.Values(value...)
 	return b
@@ -2,6 +2,7 @@ package fjord
 
 import (
 	"testing"
+	"time"
 
 	"github.com/iktakahiro/fjord/dialect"
 	"github.com/stretchr/testify/assert"
@@ -26,6 +27,124 @@ func TestInsertStmt(t *testing.T) {
 	assert.Equal(t, []interface{}{1, "one", "eins", 2, "two", "zwei"}, buf.Value())
 }
 
+type insertJSONTest struct {
+	ID      int64
+	Payload map[string]int `db:"payload,json"`
+	Tags    *[]string      `db:"tags,json"`
+}
+
+func TestInsertStmtJSON(t *testing.T) {
+	buf := NewBuffer()
+	builder := InsertInto("table").Columns("id", "payload", "tags").Record(&insertJSONTest{
+		ID:      1,
+		Payload: map[string]int{"a": 1},
+	})
+	err := builder.Build(dialect.MySQL, buf)
+	assert.NoError(t, err)
+
+	query, err := InterpolateForDialect(buf.String(), buf.Value(), dialect.MySQL)
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO `table` (`id`,`payload`,`tags`) VALUES (1,'{\\\"a\\\":1}',NULL)", query)
+}
+
+type insertOptionTest struct {
+	ID        int64  `db:"id,omitempty"`
+	Name      string `db:"name"`
+	Note      string `db:"note,omitempty"`
+	Total     int    `db:"total,readonly"`
+	CreatedAt string `db:"created_at,-insert"`
+	UpdatedBy string `db:"updated_by,-update"`
+}
+
+func TestInsertStmtTagOptions(t *testing.T) {
+	buf := NewBuffer()
+	builder := InsertInto("table").
+		Columns("id", "name", "note", "total", "created_at", "updated_by").
+		Record(&insertOptionTest{Name: "one", Total: 1, CreatedAt: "now", UpdatedBy: "admin"}).
+		Record(&insertOptionTest{ID: 2, Name: "two", Note: "note"})
+	err := builder.Build(dialect.PostgreSQL, buf)
+	assert.NoError(t, err)
+
+	query, err := InterpolateForDialect(buf.String(), buf.Value(), dialect.PostgreSQL)
+	assert.NoError(t, err)
+	assert.Equal(t, `INSERT INTO "table" ("id","name","note","total","created_at","updated_by") VALUES `+
+		`(DEFAULT,'one',DEFAULT,DEFAULT,DEFAULT,'admin'), (2,'two','note',DEFAULT,DEFAULT,'')`, query)
+}
+
+func TestInsertStmtOnConflictUpdate(t *testing.T) {
+	for _, test := range []struct {
+		dialect Dialect
+		column  []string
+		want    string
+	}{
+		{
+			dialect: dialect.MySQL,
+			column:  []string{"b"},
+			want:    "INSERT INTO `table` (`a`,`b`) VALUES (?,?) ON DUPLICATE KEY UPDATE `b` = VALUES(`b`)",
+		},
+		{
+			dialect: dialect.MySQL,
+			want:    "INSERT INTO `table` (`a`,`b`) VALUES (?,?) ON DUPLICATE KEY UPDATE `a` = VALUES(`a`)",
+		},
+		{
+			dialect: dialect.PostgreSQL,
+			column:  []string{"b"},
+			want:    `INSERT INTO "table" ("a","b") VALUES (?,?) ON CONFLICT ("a") DO UPDATE SET "b" = EXCLUDED."b"`,
+		},
+		{
+			dialect: dialect.PostgreSQL,
+			want:    `INSERT INTO "table" ("a","b") VALUES (?,?) ON CONFLICT ("a") DO NOTHING`,
+		},
+	} {
+		buf := NewBuffer()
+		builder := InsertInto("table").Columns("a", "b").Values(1, 2).OnConflictUpdate([]string{"a"}, test.column...)
+		err := builder.Build(test.dialect, buf)
+		assert.NoError(t, err)
+		assert.Equal(t, test.want, buf.String())
+	}
+}
+
+type insertTimestampTest struct {
+	Name      string    `db:"name"`
+	CreatedAt time.Time `db:"created_at,autocreate"`
+	UpdatedAt NullTime  `db:"updated_at,autoupdate"`
+	CreatedOn int64     `db:"created_on,autocreate"`
+}
+
+func TestInsertStmtTimestamp(t *testing.T) {
+	now := time.Date(2017, 4, 1, 12, 0, 0, 0, time.UTC)
+	record := &insertTimestampTest{Name: "one"}
+
+	buf := NewBuffer()
+	builder := InsertInto("table").Columns("name", "created_at", "updated_at", "created_on")
+	builder.Clock = func() time.Time { return now }
+	err := builder.Record(record).Build(dialect.MySQL, buf)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"one", now, NullTime{Time: now, Valid: true}, now.Unix()}, buf.Value())
+	assert.Equal(t, now, record.CreatedAt)
+	assert.Equal(t, now, record.UpdatedAt.Time)
+	assert.Equal(t, now.Unix(), record.CreatedOn)
+
+	buf = NewBuffer()
+	err = InsertInto("table").Columns("name", "created_at", "updated_at").Record(&insertTimestampTest{}).Build(dialect.PostgreSQL, buf)
+	assert.NoError(t, err)
+	query, err := InterpolateForDialect(buf.String(), buf.Value(), dialect.PostgreSQL)
+	assert.NoError(t, err)
+	assert.Equal(t, `INSERT INTO "table" ("name","created_at","updated_at") VALUES ('',NOW(),NOW())`, query)
+
+	// preset values are kept
+	created := time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC)
+	record = &insertTimestampTest{Name: "two", CreatedAt: created, CreatedOn: 1}
+	buf = NewBuffer()
+	builder = InsertInto("table").Columns("name", "created_at", "updated_at", "created_on")
+	builder.Clock = func() time.Time { return now }
+	err = builder.Record(record).Build(dialect.MySQL, buf)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"two", created, NullTime{Time: now, Valid: true}, int64(1)}, buf.Value())
+	assert.Equal(t, created, record.CreatedAt)
+	assert.EqualValues(t, 1, record.CreatedOn)
+}
+
 func BenchmarkInsertValuesSQL(b *testing.B) {
 	buf := NewBuffer()
 	for i := 0; i < b.N; i++ {
@@ -43,3 +162,181 @@ func BenchmarkInsertRecordSQL(b *testing.B) {
 		}).Build(dialect.MySQL, buf)
 	}
 }
+
+func TestInsertBuilderBatchSize(t *testing.T) {
+	sess := (&Connection{Dialect: dialect.PostgreSQL, EventReceiver: nullReceiver}).NewSession(nil)
+
+	for _, test := range []struct {
+		builder *InsertBuilder
+		size    int
+	}{
+		{
+			builder: sess.InsertInto("table").Columns("a", "b", "c"),
+			size:    0,
+		},
+		{
+			builder: sess.InsertInto("table").Columns("a", "b", "c").BatchRows(100),
+			size:    100,
+		},
+		{
+			builder: sess.InsertInto("table").Columns("a", "b", "c").BatchParams(65535),
+			size:    21845,
+		},
+		{
+			builder: sess.InsertInto("table").Columns("a", "b", "c").BatchRows(100).BatchParams(30),
+			size:    10,
+		},
+		{
+			builder: sess.InsertInto("table").Columns("a", "b", "c").BatchParams(2),
+			size:    1,
+		},
+	} {
+		assert.Equal(t, test.size, test.builder.batchSize())
+	}
+}
+
+func TestInsertStmtRecords(t *testing.T) {
+	for _, records := range []interface{}{
+		[]insertOptionTest{{Name: "one"}, {ID: 2, Name: "two"}},
+		&[]*insertOptionTest{{Name: "one"}, nil, {ID: 2, Name: "two"}},
+	} {
+		buf := NewBuffer()
+		builder := InsertInto("table").Columns("id", "name").Records(records)
+		err := builder.Build(dialect.MySQL, buf)
+		assert.NoError(t, err)
+
+		query, err := InterpolateForDialect(buf.String(), buf.Value(), dialect.MySQL)
+		assert.NoError(t, err)
+		assert.Equal(t, "INSERT INTO `table` (`id`,`name`) VALUES (DEFAULT,'one'), (2,'two')", query)
+	}
+}
+
+func TestInsertBuilderRecords(t *testing.T) {
+	sess := (&Connection{Dialect: dialect.PostgreSQL, EventReceiver: nullReceiver}).NewSession(nil)
+
+	records := []insertOptionTest{{Name: "one"}, {Name: "two"}}
+	builder := sess.InsertInto("table").Columns("name").Records(records)
+	assert.Equal(t, "id", builder.idColumn)
+	assert.Len(t, builder.backfillID(), 2)
+	builder.backfillID()[1].SetInt(2)
+	assert.EqualValues(t, 2, records[1].ID)
+
+	builder.returning = builder.idColumn
+	buf := NewBuffer()
+	assert.NoError(t, builder.Build(dialect.PostgreSQL, buf))
+	assert.Equal(t, `INSERT INTO "table" ("name") VALUES (?), (?) RETURNING "id"`, buf.String())
+
+	// rows added by Values are not back-filled
+	builder.Values("three")
+	assert.Nil(t, builder.backfillID())
+
+	// rows which may be skipped or updated are not back-filled
+	builder = sess.InsertInto("table").Columns("name").Records(records).Ignore()
+	assert.Nil(t, builder.backfillID())
+	builder = sess.InsertInto("table").Columns("id", "name").Records(records).OnConflictUpdate([]string{"id"}, "name")
+	assert.Nil(t, builder.backfillID())
+
+	_, err := sess.InsertInto("table").Columns("name").Records(insertOptionTest{}).Exec()
+	assert.Equal(t, ErrInvalidRecords, err)
+}
+
+func TestInsertStmtFromSelect(t *testing.T) {
+	sel := Select("id", "name").From("person").Where(Gt("id", 10))
+
+	for _, test := range []struct {
+		stmt  *InsertStmt
+		d     Dialect
+		query string
+	}{
+		{
+			stmt:  InsertInto("archive").Columns("id", "name").FromSelect(sel),
+			d:     dialect.MySQL,
+			query: "INSERT INTO `archive` (`id`,`name`) (SELECT id, name FROM person WHERE (`id` > 10))",
+		},
+		{
+			stmt:  InsertInto("archive").FromSelect(Select("*").From("person")),
+			d:     dialect.PostgreSQL,
+			query: `INSERT INTO "archive" (SELECT * FROM person)`,
+		},
+		{
+			stmt: InsertInto("archive").Columns("id", "name").FromSelect(sel).
+				OnConflictUpdate([]string{"id"}, "name"),
+			d: dialect.PostgreSQL,
+			query: `INSERT INTO "archive" ("id","name") (SELECT id, name FROM person WHERE ("id" > 10)) ` +
+				`ON CONFLICT ("id") DO UPDATE SET "name" = EXCLUDED."name"`,
+		},
+	} {
+		query, err := InterpolateForDialect("?", []interface{}{test.stmt}, test.d)
+		assert.NoError(t, err)
+		assert.Equal(t, test.query, query)
+	}
+
+	buf := NewBuffer()
+	err := InsertInto("archive").Columns("id").FromSelect(sel).Build(dialect.MySQL, buf)
+	assert.Equal(t, ErrColumnCount, err)
+
+	buf = NewBuffer()
+	err = InsertInto("archive").Columns("id").FromSelect(Select(I("id"), I("name").As("title")).From("person")).Build(dialect.MySQL, buf)
+	assert.Equal(t, ErrColumnCount, err)
+
+	// raw columns are not counted
+	for _, sel := range []*SelectStmt{
+		Select("id, name").From("person"),
+		Select(Expr("id, name")).From("person"),
+		Select("*").From("person"),
+	} {
+		buf = NewBuffer()
+		err = InsertInto("archive").Columns("id", "name").FromSelect(sel).Build(dialect.MySQL, buf)
+		assert.NoError(t, err)
+	}
+
+	buf = NewBuffer()
+	err = InsertInto("archive").Columns("id", "name").Values(1, "a").FromSelect(sel).Build(dialect.MySQL, buf)
+	assert.Equal(t, ErrInsertSource, err)
+}
+
+func TestInsertStmtIgnore(t *testing.T) {
+	for _, test := range []struct {
+		stmt  *InsertStmt
+		d     Dialect
+		query string
+	}{
+		{
+			stmt:  InsertInto("table").Columns("a", "b").Values(1, Default).Ignore(),
+			d:     dialect.MySQL,
+			query: "INSERT IGNORE INTO `table` (`a`,`b`) VALUES (1,DEFAULT)",
+		},
+		{
+			stmt:  InsertInto("table").Columns("a", "b").Values(1, Default).Ignore(),
+			d:     dialect.PostgreSQL,
+			query: `INSERT INTO "table" ("a","b") VALUES (1,DEFAULT) ON CONFLICT DO NOTHING`,
+		},
+		{
+			stmt:  InsertInto("table").DefaultValues(),
+			d:     dialect.MySQL,
+			query: "INSERT INTO `table` () VALUES ()",
+		},
+		{
+			stmt:  InsertInto("table").DefaultValues(),
+			d:     dialect.PostgreSQL,
+			query: `INSERT INTO "table" DEFAULT VALUES`,
+		},
+		{
+			stmt:  InsertInto("table").DefaultValues().Ignore(),
+			d:     dialect.PostgreSQL,
+			query: `INSERT INTO "table" DEFAULT VALUES ON CONFLICT DO NOTHING`,
+		},
+	} {
+		query, err := InterpolateForDialect("?", []interface{}{test.stmt}, test.d)
+		assert.NoError(t, err)
+		assert.Equal(t, test.query, query)
+	}
+
+	buf := NewBuffer()
+	err := InsertInto("table").Columns("a").Values(1).Ignore().OnConflictUpdate([]string{"a"}).Build(dialect.MySQL, buf)
+	assert.Equal(t, ErrNotSupported, err)
+
+	buf = NewBuffer()
+	err = InsertInto("table").Columns("a").DefaultValues().Build(dialect.MySQL, buf)
+	assert.Equal(t, ErrInsertSource, err)
+}
@@ -72,7 +72,7 @@ func (i *interpolator) encodePlaceholder(value interface{}) error {
 		paren := true
 		switch value.(type) {
 		case *SelectStmt:
-		case *union:
+		case *CompoundStmt:
 		default:
 			paren = false
 		}
@@ -21,13 +21,7 @@ func join(t joinType, table interface{}, on interface{}) Builder {
 			buf.WriteString("FULL ")
 		}
 		buf.WriteString("JOIN ")
-		switch table := table.(type) {
-		case string:
-			buf.WriteString(d.QuoteIdent(table))
-		default:
-			buf.WriteString(placeholder)
-			buf.WriteValue(table)
-		}
+		buildTable(d, buf, table)
 		buf.WriteString(" ON ")
 		switch on := on.(type) {
 		case string:
@@ -39,3 +33,49 @@ func join(t joinType, table interface{}, on interface{}) Builder {
 		return nil
 	})
 }
+
+func buildTable(d Dialect, buf Buffer, table interface{}) {
+	switch table := table.(type) {
+	case string:
+		buf.WriteString(d.QuoteIdent(table))
+	default:
+		buf.WriteString(placeholder)
+		buf.WriteValue(table)
+	}
+}
+
+// joinTable is a table joined to UPDATE or DELETE
+type joinTable struct {
+	table interface{}
+	on    interface{}
+}
+
+// buildJoinTables builds `JOIN table ON ...` for each table
+func buildJoinTables(d Dialect, buf Buffer, tables []joinTable) error {
+	for _, t := range tables {
+		err := join(inner, t.table, t.on).Build(d, buf)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// buildTableList builds `table1, table2`, and returns ON conditions
+// which should be added to WHERE.
+func buildTableList(d Dialect, buf Buffer, tables []joinTable) []Builder {
+	var cond []Builder
+	for i, t := range tables {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buildTable(d, buf, t.table)
+		switch on := t.on.(type) {
+		case string:
+			cond = append(cond, Expr(on))
+		case Builder:
+			cond = append(cond, on)
+		}
+	}
+	return cond
+}
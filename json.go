@@ -0,0 +1,57 @@
+package fjord
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"reflect"
+)
+
+// JSON returns a value which is marshaled into a JSON string when it is written.
+// Fields tagged with the json option, e.g. `db:"payload,json"`, are marshaled
+// in the same way by Record().
+//
+//	sess.Update("event").Set("payload", fjord.JSON(payload))
+func JSON(v interface{}) driver.Valuer {
+	return jsonValue{v: v}
+}
+
+type jsonValue struct {
+	v interface{}
+}
+
+// Value implements the driver Valuer interface.
+// A nil pointer is written as NULL.
+func (j jsonValue) Value() (driver.Value, error) {
+	if j.v == nil {
+		return nil, nil
+	}
+	if v := reflect.ValueOf(j.v); v.Kind() == reflect.Ptr && v.IsNil() {
+		return nil, nil
+	}
+	b, err := json.Marshal(j.v)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// jsonScanner unmarshals a JSON column into dest.
+type jsonScanner struct {
+	dest interface{}
+}
+
+// Scan implements the Scanner interface.
+// NULL resets dest to its zero value.
+func (j jsonScanner) Scan(value interface{}) error {
+	switch value := value.(type) {
+	case nil:
+		v := reflect.ValueOf(j.dest).Elem()
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	case []byte:
+		return json.Unmarshal(value, j.dest)
+	case string:
+		return json.Unmarshal([]byte(value), j.dest)
+	}
+	return ErrNotSupported
+}
@@ -2,11 +2,49 @@ package fjord
 
 import (
 	"database/sql"
+	"fmt"
 	"reflect"
+	"sort"
+	"strings"
+	"time"
 )
 
+// StrictMode controls how strictly Load maps result columns to struct fields.
+// The zero value keeps the lenient default behavior.
+type StrictMode uint8
+
+const (
+	// StrictColumns makes Load fail when a result column has no destination field.
+	StrictColumns StrictMode = 1 << iota
+	// StrictFields makes Load fail when a struct field has no matching result column.
+	StrictFields
+
+	// StrictAll enables both StrictColumns and StrictFields.
+	StrictAll = StrictColumns | StrictFields
+)
+
+// MappingError is returned by Load in strict mode when result columns
+// and struct fields do not match.
+type MappingError struct {
+	// UnmappedColumns are result columns with no destination field.
+	UnmappedColumns []string
+	// MissingFields are columns of struct fields that had no matching result column.
+	MissingFields []string
+}
+
+func (e *MappingError) Error() string {
+	var msg []string
+	if len(e.UnmappedColumns) > 0 {
+		msg = append(msg, "unmapped columns: "+strings.Join(e.UnmappedColumns, ", "))
+	}
+	if len(e.MissingFields) > 0 {
+		msg = append(msg, "fields with no matching column: "+strings.Join(e.MissingFields, ", "))
+	}
+	return fmt.Sprintf("fjord: strict mapping failed (%s)", strings.Join(msg, "; "))
+}
+
 // load loads any value from sql.Rows
-func load(rows *sql.Rows, value interface{}) (int, error) {
+func load(rows *sql.Rows, value interface{}, mode StrictMode) (int, error) {
 	defer rows.Close()
 
 	column, err := rows.Columns()
@@ -21,6 +59,25 @@ func load(rows *sql.Rows, value interface{}) (int, error) {
 
 	v = v.Elem()
 	isSlice := v.Kind() == reflect.Slice && v.Type().Elem().Kind() != reflect.Uint8
+	elemType := v.Type()
+	if isSlice {
+		elemType = elemType.Elem()
+	}
+	if rel := findRelations(indirectType(elemType)); len(rel) > 0 {
+		return loadRelation(rows, column, v, isSlice, rel, mode)
+	}
+
+	if mode != 0 {
+		// columns are the same for all rows, so they are checked once
+		elem := v
+		if isSlice {
+			elem = reflect.New(elemType).Elem()
+		}
+		if err := checkStrictValue(column, elem, mode); err != nil {
+			return 0, err
+		}
+	}
+
 	count := 0
 	for rows.Next() {
 		var elem reflect.Value
@@ -29,15 +86,11 @@ func load(rows *sql.Rows, value interface{}) (int, error) {
 		} else {
 			elem = v
 		}
-		ptr, err := findPtr(column, elem)
-
-		if err != nil {
-			return 0, err
-		}
-		err = rows.Scan(ptr...)
+		err = rows.Scan(findPtr(column, elem)...)
 		if err != nil {
 			return 0, err
 		}
+		takeSnapshot(elem)
 		count++
 		if isSlice {
 			v.Set(reflect.Append(v, elem))
@@ -57,24 +110,25 @@ func (dummyScanner) Scan(interface{}) error {
 var (
 	dummyDest   sql.Scanner = dummyScanner{}
 	typeScanner             = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	typeTime                = reflect.TypeOf(time.Time{})
 )
 
-func findPtr(column []string, value reflect.Value) ([]interface{}, error) {
+func findPtr(column []string, value reflect.Value) []interface{} {
 	if value.Addr().Type().Implements(typeScanner) {
-		return []interface{}{value.Addr().Interface()}, nil
+		return []interface{}{value.Addr().Interface()}
 	}
 	switch value.Kind() {
 	case reflect.Struct:
 		var ptr []interface{}
-		m := structMap(value, false)
+		m := structFieldMap(value, false)
 		for _, key := range column {
-			if val, ok := m[key]; ok {
-				ptr = append(ptr, val.Addr().Interface())
+			if field, ok := m[key]; ok {
+				ptr = append(ptr, field.ptr())
 			} else {
 				ptr = append(ptr, dummyDest)
 			}
 		}
-		return ptr, nil
+		return ptr
 
 	case reflect.Ptr:
 		if value.IsNil() {
@@ -83,5 +137,64 @@ func findPtr(column []string, value reflect.Value) ([]interface{}, error) {
 		return findPtr(column, value.Elem())
 	}
 
-	return []interface{}{value.Addr().Interface()}, nil
+	return []interface{}{value.Addr().Interface()}
+}
+
+// checkStrictValue checks result columns against the fields which findPtr
+// would map for value. value is not modified.
+func checkStrictValue(column []string, value reflect.Value, mode StrictMode) error {
+	if value.Addr().Type().Implements(typeScanner) {
+		return nil
+	}
+	switch value.Kind() {
+	case reflect.Struct:
+		return checkStrict(column, structFieldMap(value, false), mode)
+	case reflect.Ptr:
+		if value.IsNil() {
+			return checkStrictValue(column, reflect.New(value.Type().Elem()).Elem(), mode)
+		}
+		return checkStrictValue(column, value.Elem(), mode)
+	}
+	return nil
+}
+
+// checkStrict compares result columns with the columns of struct fields.
+func checkStrict(column []string, m map[string]structField, mode StrictMode) error {
+	var e MappingError
+	if mode&StrictColumns != 0 {
+		for _, key := range column {
+			if _, ok := m[key]; !ok {
+				e.UnmappedColumns = append(e.UnmappedColumns, key)
+			}
+		}
+	}
+	if mode&StrictFields != 0 {
+		found := make(map[string]bool, len(column))
+		for _, key := range column {
+			found[key] = true
+		}
+		for key, field := range m {
+			if !found[key] && !field.isContainer() {
+				e.MissingFields = append(e.MissingFields, key)
+			}
+		}
+		sort.Strings(e.MissingFields)
+	}
+	if len(e.UnmappedColumns) > 0 || len(e.MissingFields) > 0 {
+		return &e
+	}
+	return nil
+}
+
+// isContainer reports whether a field only groups other mapped fields,
+// like an embedded struct, rather than receiving a column itself.
+func isContainer(value reflect.Value) bool {
+	t := value.Type()
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || t == typeTime {
+		return false
+	}
+	return !reflect.PtrTo(t).Implements(typeScanner)
 }
@@ -0,0 +1,33 @@
+package fjord
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckStrictValue(t *testing.T) {
+	type record struct {
+		ID   int64
+		Name string
+	}
+
+	var r record
+	assert.NoError(t, checkStrictValue([]string{"id", "name"}, reflect.ValueOf(&r).Elem(), StrictAll))
+
+	err := checkStrictValue([]string{"id", "email"}, reflect.ValueOf(&r).Elem(), StrictAll)
+	assert.Equal(t, &MappingError{UnmappedColumns: []string{"email"}, MissingFields: []string{"name"}}, err)
+
+	// a nil pointer is checked by its element type, and left nil
+	var p *record
+	err = checkStrictValue([]string{"id"}, reflect.ValueOf(&p).Elem(), StrictFields)
+	assert.Equal(t, &MappingError{MissingFields: []string{"name"}}, err)
+	assert.Nil(t, p)
+
+	// scanners and scalars are not checked
+	var s NullString
+	assert.NoError(t, checkStrictValue([]string{"a", "b"}, reflect.ValueOf(&s).Elem(), StrictAll))
+	var n int
+	assert.NoError(t, checkStrictValue([]string{"a", "b"}, reflect.ValueOf(&n).Elem(), StrictAll))
+}
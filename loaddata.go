@@ -0,0 +1,171 @@
+package fjord
+
+import (
+	"bufio"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"reflect"
+	"sync/atomic"
+	"time"
+)
+
+// ReaderRegistry registers io.Reader handlers to a MySQL driver for
+// `LOAD DATA LOCAL INFILE 'Reader::name'`. With github.com/go-sql-driver/mysql,
+//
+//	fjord.ReaderRegistry{
+//		Register:   mysql.RegisterReaderHandler,
+//		Deregister: mysql.DeregisterReaderHandler,
+//	}
+type ReaderRegistry struct {
+	Register   func(name string, handler func() io.Reader)
+	Deregister func(name string)
+}
+
+// loadDataReaderID makes reader names unique
+var loadDataReaderID uint64
+
+// loadDataReaderName returns a new name to register a reader
+func loadDataReaderName() string {
+	return fmt.Sprintf("fjord_%d", atomic.AddUint64(&loadDataReaderID, 1))
+}
+
+// loadDataQuery builds `LOAD DATA LOCAL INFILE 'Reader::name' INTO TABLE table (column)`
+func loadDataQuery(d Dialect, name, table string, column []string) string {
+	buf := NewBuffer()
+	buf.WriteString("LOAD DATA LOCAL INFILE ")
+	buf.WriteString(d.EncodeString("Reader::" + name))
+	buf.WriteString(" INTO TABLE ")
+	buf.WriteString(d.QuoteIdent(table))
+	buf.WriteString(" (")
+	for i, col := range column {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		buf.WriteString(d.QuoteIdent(col))
+	}
+	buf.WriteString(")")
+	return buf.String()
+}
+
+// execLoadData registers a reader which streams rows, and runs the query.
+func (b *CopyBuilder) execLoadData(name, query string, value [][]interface{}) (sql.Result, error) {
+	registry := b.LocalInfileReaders
+	if registry.Register == nil || registry.Deregister == nil {
+		return nil, ErrNotSupported
+	}
+	registry.Register(name, func() io.Reader {
+		r, w := io.Pipe()
+		go func() {
+			w.CloseWithError(writeTSV(w, value))
+		}()
+		return r
+	})
+	defer registry.Deregister(name)
+
+	return b.runner.Exec(query)
+}
+
+// writeTSV writes rows in the default format of LOAD DATA, in which fields
+// are separated by tab, lines by newline, and NULL is written as \N.
+func writeTSV(w io.Writer, value [][]interface{}) error {
+	bw := bufio.NewWriter(w)
+	for _, row := range value {
+		for i, v := range row {
+			if i > 0 {
+				bw.WriteByte('\t')
+			}
+			if err := writeTSVValue(bw, v); err != nil {
+				return err
+			}
+		}
+		bw.WriteByte('\n')
+	}
+	return bw.Flush()
+}
+
+func writeTSVValue(w *bufio.Writer, v interface{}) error {
+	if _, ok := v.(Builder); ok {
+		return ErrNotSupported
+	}
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && rv.IsNil() {
+		w.WriteString(`\N`)
+		return nil
+	}
+	if valuer, ok := v.(driver.Valuer); ok {
+		val, err := valuer.Value()
+		if err != nil {
+			return err
+		}
+		v = val
+	}
+
+	switch v := v.(type) {
+	case nil:
+		w.WriteString(`\N`)
+		return nil
+	case string:
+		escapeTSV(w, []byte(v))
+		return nil
+	case []byte:
+		if v == nil {
+			w.WriteString(`\N`)
+			return nil
+		}
+		escapeTSV(w, v)
+		return nil
+	case time.Time:
+		w.WriteString(v.UTC().Format(timeFormat))
+		return nil
+	case bool:
+		if v {
+			w.WriteByte('1')
+		} else {
+			w.WriteByte('0')
+		}
+		return nil
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Ptr:
+		return writeTSVValue(w, rv.Elem().Interface())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		fmt.Fprint(w, v)
+		return nil
+	case reflect.String:
+		escapeTSV(w, []byte(rv.String()))
+		return nil
+	case reflect.Bool:
+		return writeTSVValue(w, rv.Bool())
+	}
+	return ErrNotSupported
+}
+
+// escapeTSV escapes special characters with backslash
+// https://dev.mysql.com/doc/refman/5.7/en/load-data.html
+func escapeTSV(w *bufio.Writer, b []byte) {
+	for _, c := range b {
+		switch c {
+		case 0:
+			w.WriteString(`\0`)
+		case '\b':
+			w.WriteString(`\b`)
+		case '\n':
+			w.WriteString(`\n`)
+		case '\r':
+			w.WriteString(`\r`)
+		case '\t':
+			w.WriteString(`\t`)
+		case 26:
+			w.WriteString(`\Z`)
+		case '\\':
+			w.WriteString(`\\`)
+		default:
+			w.WriteByte(c)
+		}
+	}
+}
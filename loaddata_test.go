@@ -0,0 +1,87 @@
+package fjord
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/iktakahiro/fjord/dialect"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadDataQuery(t *testing.T) {
+	assert.Equal(t, "LOAD DATA LOCAL INFILE 'Reader::fjord_1' INTO TABLE `person` (`id`,`name`)",
+		loadDataQuery(dialect.MySQL, "fjord_1", "person", []string{"id", "name"}))
+	assert.NotEqual(t, loadDataReaderName(), loadDataReaderName())
+}
+
+func TestWriteTSV(t *testing.T) {
+	var nullString *string
+	buf := new(bytes.Buffer)
+	err := writeTSV(buf, [][]interface{}{
+		{1, "a\tb\nc\\d", nil, true},
+		{uint8(2), []byte("\x00\r"), NullString{}, time.Date(2017, 1, 2, 3, 4, 5, 0, time.UTC)},
+		{1.5, nullString, NewNullString("x"), NewNullInt64(3)},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "1\ta\\tb\\nc\\\\d\t\\N\t1\n"+
+		"2\t\\0\\r\t\\N\t2017-01-02 03:04:05.000000\n"+
+		"1.5\t\\N\tx\t3\n", buf.String())
+
+	err = writeTSV(buf, [][]interface{}{{Default}})
+	assert.Equal(t, ErrNotSupported, err)
+}
+
+// loadDataRunner reads the registered reader like a driver running LOAD DATA
+type loadDataRunner struct {
+	handler map[string]func() io.Reader
+	query   string
+	data    string
+}
+
+func (r *loadDataRunner) Exec(query string, args ...interface{}) (sql.Result, error) {
+	r.query = query
+	name := strings.TrimSuffix(strings.SplitN(query, "'Reader::", 2)[1], "' INTO TABLE `person` (`id`,`name`)")
+	b, err := ioutil.ReadAll(r.handler[name]())
+	if err != nil {
+		return nil, err
+	}
+	r.data = string(b)
+	return driver.RowsAffected(strings.Count(r.data, "\n")), nil
+}
+
+func (r *loadDataRunner) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return nil, ErrNotSupported
+}
+
+func TestCopyLocalInfile(t *testing.T) {
+	r := &loadDataRunner{handler: make(map[string]func() io.Reader)}
+	registry := ReaderRegistry{
+		Register: func(name string, handler func() io.Reader) {
+			r.handler[name] = handler
+		},
+		Deregister: func(name string) {
+			delete(r.handler, name)
+		},
+	}
+	sess := (&Connection{Dialect: dialect.MySQL, EventReceiver: nullReceiver}).NewSession(nil)
+	b := sess.CopyInto("person").Columns("id", "name").Values(1, "a").Values(2, "b").LocalInfile(registry)
+	b.runner = r
+
+	result, err := b.Exec()
+	assert.NoError(t, err)
+	n, err := result.RowsAffected()
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, n)
+	assert.Contains(t, r.query, "LOAD DATA LOCAL INFILE 'Reader::fjord_")
+	assert.Equal(t, "1\ta\n2\tb\n", r.data)
+	assert.Empty(t, r.handler)
+
+	_, err = sess.CopyInto("person").Columns("id").Values(1).LocalInfile(ReaderRegistry{}).Exec()
+	assert.Equal(t, ErrNotSupported, err)
+}
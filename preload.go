@@ -0,0 +1,199 @@
+package fjord
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// TableNamer can be implemented by a record type to tell its table name.
+// When it is not implemented, the snake_case type name is used.
+type TableNamer interface {
+	TableName() string
+}
+
+var typeTableNamer = reflect.TypeOf((*TableNamer)(nil)).Elem()
+
+// tableName returns the table name of a struct type
+func tableName(t reflect.Type) string {
+	t = indirectType(t)
+	if reflect.PtrTo(t).Implements(typeTableNamer) {
+		return reflect.New(t).Interface().(TableNamer).TableName()
+	}
+	return camelCaseToSnakeCase(t.Name())
+}
+
+// PreloadBuilder loads has-many relations of loaded records,
+// running one `SELECT ... WHERE foreign_key IN ?` query per relation.
+type PreloadBuilder struct {
+	runner SessionRunner
+	value  interface{}
+	step   []preloadStep
+}
+
+type preloadStep struct {
+	path       []string
+	foreignKey string
+}
+
+// Preload creates a PreloadBuilder which loads a slice field of value.
+// The children are selected from the table of the element type
+// by foreignKey, which references the field of the parent tagged with pk,
+// or the `id` column when no field is tagged.
+//
+//	sess.Preload(&orders, "Items", "order_id").Load()
+func (sess *Session) Preload(value interface{}, field, foreignKey string) *PreloadBuilder {
+	return newPreload(sess, value).Preload(field, foreignKey)
+}
+
+// Preload creates a PreloadBuilder which loads a slice field of value.
+func (tx *Tx) Preload(value interface{}, field, foreignKey string) *PreloadBuilder {
+	return newPreload(tx, value).Preload(field, foreignKey)
+}
+
+func newPreload(runner SessionRunner, value interface{}) *PreloadBuilder {
+	return &PreloadBuilder{
+		runner: runner,
+		value:  value,
+	}
+}
+
+// Preload adds a relation to load.
+// A nested relation is specified by a dotted path, e.g. "Items.Tags".
+func (b *PreloadBuilder) Preload(field, foreignKey string) *PreloadBuilder {
+	b.step = append(b.step, preloadStep{
+		path:       strings.Split(field, "."),
+		foreignKey: foreignKey,
+	})
+	return b
+}
+
+// Load runs queries in the order relations were added,
+// and returns the total count of loaded children.
+func (b *PreloadBuilder) Load() (int, error) {
+	v := reflect.ValueOf(b.value)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return 0, ErrInvalidPointer
+	}
+
+	total := 0
+	for _, step := range b.step {
+		parent := collectParents(v, step.path[:len(step.path)-1])
+		count, err := b.load(parent, step.path[len(step.path)-1], step.foreignKey)
+		if err != nil {
+			return total, err
+		}
+		total += count
+	}
+	return total, nil
+}
+
+func (b *PreloadBuilder) load(parent []reflect.Value, field, foreignKey string) (int, error) {
+	if len(parent) == 0 {
+		return 0, nil
+	}
+	sf, ok := parent[0].Type().FieldByName(field)
+	if !ok || sf.Type.Kind() != reflect.Slice || indirectType(sf.Type.Elem()).Kind() != reflect.Struct {
+		return 0, ErrInvalidPreload
+	}
+
+	var key []interface{}
+	parentByKey := make(map[interface{}][]reflect.Value)
+	for _, p := range parent {
+		id, ok := preloadParentKey(p)
+		if !ok {
+			return 0, ErrInvalidPreload
+		}
+		k := preloadKey(id)
+		if _, ok := parentByKey[k]; !ok {
+			key = append(key, id.Interface())
+		}
+		parentByKey[k] = append(parentByKey[k], p)
+		// loaded children replace previous ones
+		p.FieldByIndex(sf.Index).Set(reflect.Zero(sf.Type))
+	}
+
+	children := reflect.New(sf.Type)
+	count, err := b.runner.Select("*").
+		From(tableName(sf.Type.Elem())).
+		Where(Eq(foreignKey, key)).
+		Load(children.Interface())
+	if err != nil {
+		return 0, err
+	}
+
+	children = children.Elem()
+	for i := 0; i < children.Len(); i++ {
+		child := children.Index(i)
+		fk, ok := structMap(child, true)[foreignKey]
+		if !ok {
+			return 0, ErrInvalidPreload
+		}
+		for _, p := range parentByKey[preloadKey(fk)] {
+			slice := p.FieldByIndex(sf.Index)
+			slice.Set(reflect.Append(slice, child))
+		}
+	}
+	return count, nil
+}
+
+// preloadParentKey returns the value of the field tagged with pk,
+// or the `id` column when no field is tagged.
+// Composite primary keys are not supported.
+func preloadParentKey(parent reflect.Value) (reflect.Value, bool) {
+	var key []reflect.Value
+	m := structFieldMap(parent, true)
+	for _, field := range m {
+		if field.isPK() {
+			key = append(key, field.value)
+		}
+	}
+	switch len(key) {
+	case 0:
+		field, ok := m["id"]
+		return field.value, ok
+	case 1:
+		return key[0], true
+	}
+	return reflect.Value{}, false
+}
+
+// collectParents walks slice fields along path, and returns addressable structs.
+func collectParents(v reflect.Value, path []string) []reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return nil
+		}
+		return collectParents(v.Elem(), path)
+	case reflect.Slice:
+		var parent []reflect.Value
+		for i := 0; i < v.Len(); i++ {
+			parent = append(parent, collectParents(v.Index(i), path)...)
+		}
+		return parent
+	case reflect.Struct:
+		if len(path) == 0 {
+			return []reflect.Value{v}
+		}
+		field := v.FieldByName(path[0])
+		if !field.IsValid() {
+			return nil
+		}
+		return collectParents(field, path[1:])
+	}
+	return nil
+}
+
+// preloadKey converts a key value so that parent keys and foreign keys
+// of different types can be matched.
+func preloadKey(v reflect.Value) interface{} {
+	value := v.Interface()
+	if valuer, ok := value.(driver.Valuer); ok {
+		if val, err := valuer.Value(); err == nil {
+			value = val
+		}
+	}
+	return fmt.Sprint(value)
+}
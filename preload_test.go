@@ -0,0 +1,40 @@
+package fjord
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPreloadParentKey(t *testing.T) {
+	type byID struct {
+		ID   int64
+		Name string
+	}
+	type byPK struct {
+		No int64 `db:"no,pk"`
+		ID int64
+	}
+	type composite struct {
+		A int64 `db:"a,pk"`
+		B int64 `db:"b,pk"`
+	}
+	type noKey struct {
+		Name string
+	}
+
+	key, ok := preloadParentKey(reflect.ValueOf(byID{ID: 1}))
+	assert.True(t, ok)
+	assert.EqualValues(t, 1, key.Interface())
+
+	key, ok = preloadParentKey(reflect.ValueOf(byPK{No: 2, ID: 1}))
+	assert.True(t, ok)
+	assert.EqualValues(t, 2, key.Interface())
+
+	_, ok = preloadParentKey(reflect.ValueOf(composite{}))
+	assert.False(t, ok)
+
+	_, ok = preloadParentKey(reflect.ValueOf(noKey{}))
+	assert.False(t, ok)
+}
@@ -0,0 +1,334 @@
+package fjord
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// relation is a has-many field which is hydrated from joined rows.
+//
+//	type Person struct {
+//		ID    int64  `db:"p.id"`
+//		Name  string `db:"p.name"`
+//		Roles []Role `db:"r" key:"p.id"`
+//	}
+//
+// The db tag is the table alias of child columns, and the key tag is
+// the parent column which identifies a parent row.
+type relation struct {
+	index  []int
+	prefix string
+	key    string
+	elem   reflect.Type
+}
+
+// isRelation reports whether a struct field is a has-many relation.
+func isRelation(field reflect.StructField) bool {
+	if field.Tag.Get("key") == "" || field.Type.Kind() != reflect.Slice {
+		return false
+	}
+	return indirectType(field.Type.Elem()).Kind() == reflect.Struct
+}
+
+func indirectType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Ptr {
+		return t.Elem()
+	}
+	return t
+}
+
+// findRelations finds has-many relations in a struct type and its embedded structs.
+func findRelations(t reflect.Type) []relation {
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	var rel []relation
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" && !field.Anonymous {
+			// unexported
+			continue
+		}
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			for _, r := range findRelations(field.Type) {
+				r.index = append([]int{i}, r.index...)
+				rel = append(rel, r)
+			}
+			continue
+		}
+		if !isRelation(field) {
+			continue
+		}
+		prefix, _ := parseTag(field.Tag.Get("db"))
+		rel = append(rel, relation{
+			index:  []int{i},
+			prefix: prefix + "__",
+			key:    columnNameToAlias(field.Tag.Get("key")),
+			elem:   field.Type.Elem(),
+		})
+	}
+	return rel
+}
+
+// relationPtr is a scan destination for a child column.
+// It is a pointer to a pointer so that NULL can be detected.
+// A JSON column is scanned as *[]byte, and unmarshaled later.
+type relationPtr struct {
+	column string
+	value  reflect.Value
+	json   bool
+	pk     bool
+}
+
+var typeBytesPtr = reflect.TypeOf((*[]byte)(nil))
+
+// loadRelation loads joined rows, folding them into parents which have child slices.
+// Parents are deduplicated by their key columns, identical children are
+// deduplicated, and children whose columns are all NULL are skipped.
+func loadRelation(rows *sql.Rows, column []string, v reflect.Value, isSlice bool, rel []relation, mode StrictMode) (int, error) {
+	var keyColumn []string
+	for _, r := range rel {
+		if !containsString(keyColumn, r.key) {
+			keyColumn = append(keyColumn, r.key)
+		}
+	}
+
+	elemType := v.Type()
+	if isSlice {
+		elemType = elemType.Elem()
+	}
+
+	parentIndex := make(map[interface{}]int)
+	seen := make(map[string]bool)
+	count := 0
+	for rows.Next() {
+		elem := reflect.New(elemType).Elem()
+		if !isSlice && count == 0 {
+			elem = v
+		}
+		parent := elem
+		if parent.Kind() == reflect.Ptr {
+			if parent.IsNil() {
+				parent.Set(reflect.New(parent.Type().Elem()))
+			}
+			parent = parent.Elem()
+		}
+
+		m := structFieldMap(parent, false)
+		childMap := make([]map[string]structField, len(rel))
+		for i, r := range rel {
+			childMap[i] = structFieldMap(reflect.New(indirectType(r.elem)).Elem(), false)
+		}
+		var ptr []interface{}
+		childPtr := make([][]relationPtr, len(rel))
+		for _, key := range column {
+			if field, ok := m[key]; ok {
+				ptr = append(ptr, field.ptr())
+				continue
+			}
+			found := false
+			for i, r := range rel {
+				if !strings.HasPrefix(key, r.prefix) {
+					continue
+				}
+				childKey := key
+				field, ok := childMap[i][childKey]
+				if !ok {
+					childKey = strings.TrimPrefix(key, r.prefix)
+					field, ok = childMap[i][childKey]
+				}
+				if !ok {
+					continue
+				}
+				isJSON := field.options.Contains("json")
+				var p reflect.Value
+				if isJSON {
+					p = reflect.New(typeBytesPtr)
+				} else {
+					p = reflect.New(reflect.PtrTo(field.value.Type()))
+				}
+				childPtr[i] = append(childPtr[i], relationPtr{column: childKey, value: p, json: isJSON, pk: field.isPK()})
+				ptr = append(ptr, p.Interface())
+				found = true
+				break
+			}
+			if !found {
+				ptr = append(ptr, dummyDest)
+			}
+		}
+
+		if mode != 0 && count == 0 {
+			all := make(map[string]structField)
+			for key, field := range m {
+				all[key] = field
+			}
+			for i, r := range rel {
+				for key, field := range childMap[i] {
+					if !strings.HasPrefix(key, r.prefix) {
+						key = r.prefix + key
+					}
+					all[key] = field
+				}
+			}
+			if err := checkStrict(column, all, mode); err != nil {
+				return 0, err
+			}
+		}
+
+		err := rows.Scan(ptr...)
+		if err != nil {
+			return 0, err
+		}
+
+		var keyValue []reflect.Value
+		for _, key := range keyColumn {
+			field, ok := m[key]
+			if !ok || !containsString(column, key) {
+				return 0, ErrRelationKeyNotFound
+			}
+			keyValue = append(keyValue, field.value)
+		}
+		key := rowKey(keyValue)
+
+		pos, ok := parentIndex[key]
+		if !ok {
+			if !isSlice && count > 0 {
+				// only rows of the first parent are folded into a struct
+				continue
+			}
+			takeSnapshot(elem)
+			if isSlice {
+				v.Set(reflect.Append(v, elem))
+				pos = v.Len() - 1
+			}
+			parentIndex[key] = pos
+			count++
+		}
+
+		target := v
+		if isSlice {
+			target = v.Index(pos)
+		}
+		if target.Kind() == reflect.Ptr {
+			target = target.Elem()
+		}
+
+		for i, r := range rel {
+			child, childKey, ok, err := buildChild(r, childPtr[i])
+			if err != nil {
+				return 0, err
+			}
+			if !ok {
+				continue
+			}
+			dedup := fmt.Sprintf("%#v/%d/%s", key, i, childKey)
+			if seen[dedup] {
+				continue
+			}
+			seen[dedup] = true
+
+			field := target.FieldByIndex(r.index)
+			field.Set(reflect.Append(field, child))
+		}
+	}
+	return count, nil
+}
+
+// buildChild creates a child from scanned columns, and returns a key which
+// identifies the child: its pk columns, or all columns when it has no pk.
+// It returns false when all columns of the child are NULL.
+func buildChild(r relation, ptr []relationPtr) (reflect.Value, string, bool, error) {
+	var value, pk []interface{}
+	for _, p := range ptr {
+		var val interface{}
+		if !p.value.Elem().IsNil() {
+			val = p.value.Elem().Elem().Interface()
+		}
+		value = append(value, val)
+		if p.pk {
+			pk = append(pk, val)
+		}
+	}
+	valid := false
+	for _, val := range value {
+		if val != nil {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return reflect.Value{}, "", false, nil
+	}
+	if len(pk) == 0 {
+		pk = value
+	}
+
+	child := reflect.New(indirectType(r.elem))
+	m := structMap(child.Elem(), false)
+	for _, p := range ptr {
+		if p.value.Elem().IsNil() {
+			continue
+		}
+		if p.json {
+			err := json.Unmarshal(p.value.Elem().Elem().Bytes(), m[p.column].Addr().Interface())
+			if err != nil {
+				return reflect.Value{}, "", false, err
+			}
+			continue
+		}
+		m[p.column].Set(p.value.Elem().Elem())
+	}
+	if r.elem.Kind() != reflect.Ptr {
+		child = child.Elem()
+	}
+	return child, valueKey(pk), true, nil
+}
+
+// rowKey converts key column values into a map key.
+func rowKey(value []reflect.Value) interface{} {
+	key := make([]interface{}, len(value))
+	for i, val := range value {
+		key[i] = val.Interface()
+	}
+	return valueKey(key)
+}
+
+// valueKey converts values into a string key. Pointers are dereferenced and
+// Valuers are converted, so that equal values in different rows have the same key.
+func valueKey(value []interface{}) string {
+	key := make([]interface{}, len(value))
+	for i, val := range value {
+		key[i] = plainValue(val)
+	}
+	return fmt.Sprintf("%#v", key)
+}
+
+func plainValue(value interface{}) interface{} {
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+		value = v.Interface()
+	}
+	if valuer, ok := value.(driver.Valuer); ok {
+		if val, err := valuer.Value(); err == nil {
+			return val
+		}
+	}
+	return value
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
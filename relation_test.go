@@ -0,0 +1,60 @@
+package fjord
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type relationPtrChild struct {
+	Name *string `db:"name"`
+}
+
+type relationPKChild struct {
+	ID   int64  `db:"id,pk"`
+	Name string `db:"name"`
+}
+
+// scanned returns a relationPtr holding value as a scanned column
+func scanned(column string, value interface{}, pk bool) relationPtr {
+	v := reflect.ValueOf(value)
+	p := reflect.New(reflect.PtrTo(v.Type()))
+	p.Elem().Set(reflect.New(v.Type()))
+	p.Elem().Elem().Set(v)
+	return relationPtr{column: column, value: p, pk: pk}
+}
+
+func TestBuildChildKey(t *testing.T) {
+	r := relation{elem: reflect.TypeOf(relationPtrChild{})}
+
+	// the same value behind different pointers has the same key
+	a, b := "admin", "admin"
+	child1, key1, ok, err := buildChild(r, []relationPtr{scanned("name", &a, false)})
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	_, key2, _, err := buildChild(r, []relationPtr{scanned("name", &b, false)})
+	assert.NoError(t, err)
+	assert.Equal(t, key1, key2)
+	assert.Equal(t, "admin", *child1.Interface().(relationPtrChild).Name)
+
+	c := "editor"
+	_, key3, _, err := buildChild(r, []relationPtr{scanned("name", &c, false)})
+	assert.NoError(t, err)
+	assert.NotEqual(t, key1, key3)
+
+	// children with pk are identified by pk
+	r = relation{elem: reflect.TypeOf(relationPKChild{})}
+	_, key1, _, err = buildChild(r, []relationPtr{scanned("id", int64(1), true), scanned("name", "a", false)})
+	assert.NoError(t, err)
+	_, key2, _, err = buildChild(r, []relationPtr{scanned("id", int64(1), true), scanned("name", "b", false)})
+	assert.NoError(t, err)
+	assert.Equal(t, key1, key2)
+}
+
+func TestRowKey(t *testing.T) {
+	a, b := int64(1), int64(1)
+	assert.Equal(t, rowKey([]reflect.Value{reflect.ValueOf(&a)}), rowKey([]reflect.Value{reflect.ValueOf(&b)}))
+	assert.Equal(t, rowKey([]reflect.Value{reflect.ValueOf(NewNullInt64(1))}), rowKey([]reflect.Value{reflect.ValueOf(NewNullInt64(1))}))
+	assert.NotEqual(t, rowKey([]reflect.Value{reflect.ValueOf(a)}), rowKey([]reflect.Value{reflect.ValueOf("1")}))
+}
@@ -1,6 +1,9 @@
 package fjord
 
-import "fmt"
+import (
+	"fmt"
+	"reflect"
+)
 
 // SelectStmt builds `SELECT ...`
 type SelectStmt struct {
@@ -15,6 +18,7 @@ type SelectStmt struct {
 	WhereCond  []Builder
 	Group      []Builder
 	HavingCond []Builder
+	Windows    []Builder
 	Order      []Builder
 
 	LimitCount  int64
@@ -104,6 +108,19 @@ func (b *SelectStmt) Build(d Dialect, buf Buffer) error {
 		}
 	}
 
+	if len(b.Windows) > 0 {
+		buf.WriteString(" WINDOW ")
+		for i, window := range b.Windows {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			err := window.Build(d, buf)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
 	if len(b.Order) > 0 {
 		buf.WriteString(" ORDER BY ")
 		for i, order := range b.Order {
@@ -138,6 +155,24 @@ func Select(column ...interface{}) *SelectStmt {
 	}
 }
 
+// SelectStruct creates a SelectStmt with columns derived from a struct
+func SelectStruct(value interface{}) *SelectStmt {
+	return Select(Columns(value)...)
+}
+
+// Columns returns select columns derived from a struct or a slice of struct
+// using the same rules as loading. e.g. `db:"p.id"` => I("p.id")
+func Columns(value interface{}) []interface{} {
+	v := reflect.Indirect(reflect.ValueOf(value))
+	if v.Kind() == reflect.Slice {
+		v = reflect.New(v.Type().Elem()).Elem()
+	}
+	if v.Kind() == reflect.Ptr {
+		v = reflect.New(v.Type().Elem()).Elem()
+	}
+	return structColumns(v)
+}
+
 // From specifies table
 func (b *SelectStmt) From(table interface{}) *SelectStmt {
 	b.Table = table
@@ -192,6 +227,12 @@ func (b *SelectStmt) GroupBy(col ...string) *SelectStmt {
 	return b
 }
 
+// Window adds a named window `WINDOW name AS (...)`, which is referenced by Over
+func (b *SelectStmt) Window(name string, window *WindowStmt) *SelectStmt {
+	b.Windows = append(b.Windows, windowDef{name: name, window: window})
+	return b
+}
+
 // OrderAsc specifies columns for ordering
 func (b *SelectStmt) OrderAsc(col string) *SelectStmt {
 	b.Order = append(b.Order, order(col, asc))
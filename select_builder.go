@@ -6,6 +6,8 @@ type SelectBuilder struct {
 	Dialect Dialect
 
 	*SelectStmt
+
+	StrictMode StrictMode
 }
 
 // TODO perhaps, Unnecessary
@@ -23,6 +25,7 @@ func (sess *Session) Select(column ...interface{}) *SelectBuilder {
 		EventReceiver: sess,
 		Dialect:       sess.Dialect,
 		SelectStmt:    Select(prepareSelect(column)...),
+		StrictMode:    sess.StrictMode,
 	}
 }
 
@@ -32,15 +35,25 @@ func (tx *Tx) Select(column ...interface{}) *SelectBuilder {
 		EventReceiver: tx,
 		Dialect:       tx.Dialect,
 		SelectStmt:    Select(prepareSelect(column)...),
+		StrictMode:    tx.StrictMode,
 	}
 }
 
+func (sess *Session) SelectStruct(value interface{}) *SelectBuilder {
+	return sess.Select(Columns(value)...)
+}
+
+func (tx *Tx) SelectStruct(value interface{}) *SelectBuilder {
+	return tx.Select(Columns(value)...)
+}
+
 func (sess *Session) SelectBySql(query string, value ...interface{}) *SelectBuilder {
 	return &SelectBuilder{
 		runner:        sess,
 		EventReceiver: sess,
 		Dialect:       sess.Dialect,
 		SelectStmt:    SelectBySql(query, value...),
+		StrictMode:    sess.StrictMode,
 	}
 }
 
@@ -50,11 +63,18 @@ func (tx *Tx) SelectBySql(query string, value ...interface{}) *SelectBuilder {
 		EventReceiver: tx,
 		Dialect:       tx.Dialect,
 		SelectStmt:    SelectBySql(query, value...),
+		StrictMode:    tx.StrictMode,
 	}
 }
 
 func (b *SelectBuilder) Load(value interface{}) (int, error) {
-	return query(b.runner, b.EventReceiver, b, b.Dialect, value)
+	return query(b.runner, b.EventReceiver, b, b.Dialect, value, b.StrictMode)
+}
+
+// Strict sets the strict mapping mode used by Load
+func (b *SelectBuilder) Strict(mode StrictMode) *SelectBuilder {
+	b.StrictMode = mode
+	return b
 }
 
 func (b *SelectBuilder) Join(table, on interface{}) *SelectBuilder {
@@ -97,6 +117,11 @@ func (b *SelectBuilder) Having(query interface{}, value ...interface{}) *SelectB
 	return b
 }
 
+func (b *SelectBuilder) Window(name string, window *WindowStmt) *SelectBuilder {
+	b.SelectStmt.Window(name, window)
+	return b
+}
+
 func (b *SelectBuilder) Limit(n uint64) *SelectBuilder {
 	b.SelectStmt.Limit(n)
 	return b
@@ -45,6 +45,25 @@ func TestSelectWithAliasStmt(t *testing.T) {
 	}
 }
 
+type selectStructTest struct {
+	ID        int64
+	Title     string `db:"subject"`
+	Ignored   string `db:"-"`
+	CreatedAt NullTime
+}
+
+func TestSelectStruct(t *testing.T) {
+	assert.Equal(t, []interface{}{"id", "subject", "created_at"}, Columns(&selectStructTest{}))
+	assert.Equal(t, []interface{}{"id", "subject", "created_at"}, Columns(&[]*selectStructTest{}))
+	assert.Equal(t, []interface{}{I("p.id"), I("p.name"), I("r.person_id"), I("r.name")}, Columns(PersonForJoin{}))
+
+	buf := NewBuffer()
+	builder := SelectStruct(PersonWithTag{}).From(I("person").As("p"))
+	err := builder.Build(dialect.MySQL, buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT `p`.`id` AS p__id, `p`.`name` AS p__name FROM ?", buf.String())
+}
+
 func BenchmarkSelectSQL(b *testing.B) {
 	buf := NewBuffer()
 	for i := 0; i < b.N; i++ {
@@ -0,0 +1,243 @@
+package fjord
+
+import (
+	"database/sql"
+	"reflect"
+)
+
+// Table is a table bound to a struct type, keyed by fields tagged with pk.
+// Its methods run on either a *Session or a *Tx.
+//
+//	type Suggestion struct {
+//		ID    int64  `db:"id,pk,omitempty"`
+//		Title string `db:"title"`
+//	}
+//
+//	suggestions := fjord.NewTable(Suggestion{})
+//	err := suggestions.Find(sess, &suggestion, 1)
+type Table struct {
+	// Name is the table name
+	Name string
+	// Column is the list of columns mapped to the struct
+	Column []string
+	// PrimaryKey is the list of columns tagged with pk
+	PrimaryKey []string
+	// DeletedAt is the column tagged with deleted_at for soft delete
+	DeletedAt string
+
+	recordType reflect.Type
+	scope      deletedScope
+}
+
+// deletedScope selects records by soft delete state
+type deletedScope uint8
+
+const (
+	withoutDeleted deletedScope = iota
+	withDeleted
+	onlyDeleted
+)
+
+// NewTable creates a Table for the struct type of record.
+// The table name is the result of TableName() when the type implements
+// TableNamer, or the snake_case type name.
+func NewTable(record interface{}) *Table {
+	t := indirectType(reflect.TypeOf(record))
+	table := &Table{
+		Name:       tableName(t),
+		recordType: t,
+	}
+	column, m := structFields(reflect.New(t).Elem(), true)
+	for _, col := range column {
+		field := m[col]
+		if field.isContainer() {
+			continue
+		}
+		table.Column = append(table.Column, col)
+		if field.isPK() {
+			table.PrimaryKey = append(table.PrimaryKey, col)
+		}
+		if field.options.Contains("deleted_at") && table.DeletedAt == "" {
+			table.DeletedAt = col
+		}
+	}
+	return table
+}
+
+// WithDeleted returns a copy of the table which selects soft deleted records too.
+func (t *Table) WithDeleted() *Table {
+	table := *t
+	table.scope = withDeleted
+	return &table
+}
+
+// OnlyDeleted returns a copy of the table which selects only soft deleted records.
+func (t *Table) OnlyDeleted() *Table {
+	table := *t
+	table.scope = onlyDeleted
+	return &table
+}
+
+// scopeCond returns a condition on soft delete, or nil when all records are in scope
+func (t *Table) scopeCond() Builder {
+	if t.DeletedAt == "" {
+		return nil
+	}
+	switch t.scope {
+	case withoutDeleted:
+		return Eq(t.DeletedAt, nil)
+	case onlyDeleted:
+		return Neq(t.DeletedAt, nil)
+	}
+	return nil
+}
+
+// whereScope adds a condition on soft delete to a select statement
+func (t *Table) whereScope(stmt *SelectBuilder) *SelectBuilder {
+	if cond := t.scopeCond(); cond != nil {
+		stmt.Where(cond)
+	}
+	return stmt
+}
+
+// wherePK creates a condition on primary key values
+func (t *Table) wherePK(value []interface{}) (Builder, error) {
+	if len(t.PrimaryKey) == 0 {
+		return nil, ErrPrimaryKeyNotFound
+	}
+	if len(value) != len(t.PrimaryKey) {
+		return nil, ErrPrimaryKeyCount
+	}
+	cond := make([]Builder, len(value))
+	for i, col := range t.PrimaryKey {
+		cond[i] = Eq(col, value[i])
+	}
+	return And(cond...), nil
+}
+
+// checkRecord checks that record is a pointer to the struct type of the table
+func (t *Table) checkRecord(record interface{}) error {
+	v := reflect.ValueOf(record)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return ErrInvalidPointer
+	}
+	if v.Elem().Type() != t.recordType {
+		return ErrRecordType
+	}
+	return nil
+}
+
+// Find loads a record by primary key values.
+// It returns ErrNotFound when there is no record.
+func (t *Table) Find(runner SessionRunner, record interface{}, pk ...interface{}) error {
+	if err := t.checkRecord(record); err != nil {
+		return err
+	}
+	cond, err := t.wherePK(pk)
+	if err != nil {
+		return err
+	}
+	stmt := runner.Select(Columns(record)...).From(I(t.Name)).Where(cond)
+	count, err := t.whereScope(stmt).Load(record)
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// FindAll loads records which match all conditions into a slice.
+func (t *Table) FindAll(runner SessionRunner, records interface{}, cond ...Builder) (int, error) {
+	stmt := runner.Select(Columns(records)...).From(I(t.Name))
+	for _, c := range cond {
+		stmt.Where(c)
+	}
+	return t.whereScope(stmt).Load(records)
+}
+
+// Exists reports whether a record with primary key values exists.
+func (t *Table) Exists(runner SessionRunner, pk ...interface{}) (bool, error) {
+	cond, err := t.wherePK(pk)
+	if err != nil {
+		return false, err
+	}
+	var one []int
+	stmt := runner.Select("1").From(I(t.Name)).Where(cond).Limit(1)
+	count, err := t.whereScope(stmt).Load(&one)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// Insert inserts a record.
+func (t *Table) Insert(runner SessionRunner, record interface{}) (sql.Result, error) {
+	if err := t.checkRecord(record); err != nil {
+		return nil, err
+	}
+	return runner.InsertInto(t.Name).Columns(t.Column...).Record(record).Exec()
+}
+
+// Update updates a record by its primary key.
+// Soft deleted records are not updated unless WithDeleted or OnlyDeleted is used.
+func (t *Table) Update(runner SessionRunner, record interface{}) (sql.Result, error) {
+	if err := t.checkRecord(record); err != nil {
+		return nil, err
+	}
+	stmt := runner.Update(t.Name).SetRecord(record).WherePK(record)
+	if cond := t.scopeCond(); cond != nil {
+		stmt.Where(cond)
+	}
+	return stmt.Exec()
+}
+
+// Upsert inserts a record, or updates it when the primary key conflicts.
+func (t *Table) Upsert(runner SessionRunner, record interface{}) (sql.Result, error) {
+	if err := t.checkRecord(record); err != nil {
+		return nil, err
+	}
+	if len(t.PrimaryKey) == 0 {
+		return nil, ErrPrimaryKeyNotFound
+	}
+	var column []string
+	m := structFieldMap(reflect.ValueOf(record).Elem(), true)
+	for _, col := range t.Column {
+		if field := m[col]; !field.isPK() && field.updatable() {
+			column = append(column, col)
+		}
+	}
+	return runner.InsertInto(t.Name).
+		Columns(t.Column...).
+		Record(record).
+		OnConflictUpdate(t.PrimaryKey, column...).
+		Exec()
+}
+
+// Delete deletes a record by its primary key.
+// When the table has a deleted_at column, the record is soft deleted by
+// setting the current time to the column.
+func (t *Table) Delete(runner SessionRunner, record interface{}) (sql.Result, error) {
+	if err := t.checkRecord(record); err != nil {
+		return nil, err
+	}
+	if t.DeletedAt == "" {
+		return runner.DeleteFrom(t.Name).Where(wherePK(record)).Exec()
+	}
+
+	field := structFieldMap(reflect.ValueOf(record).Elem(), true)[t.DeletedAt]
+	stmt := runner.Update(t.Name)
+	return stmt.Set(t.DeletedAt, field.touch(stmt.Clock)).
+		WherePK(record).
+		Where(Eq(t.DeletedAt, nil)).
+		Exec()
+}
+
+// ForceDelete deletes a record by its primary key even if the table has a deleted_at column.
+func (t *Table) ForceDelete(runner SessionRunner, record interface{}) (sql.Result, error) {
+	if err := t.checkRecord(record); err != nil {
+		return nil, err
+	}
+	return runner.DeleteFrom(t.Name).Where(wherePK(record)).Exec()
+}
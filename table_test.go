@@ -0,0 +1,91 @@
+package fjord
+
+import (
+	"testing"
+
+	"github.com/iktakahiro/fjord/dialect"
+	"github.com/stretchr/testify/assert"
+)
+
+type tableTest struct {
+	TenantID int64  `db:"tenant_id,pk"`
+	ID       int64  `db:"id,pk"`
+	Title    string `db:"title"`
+	Ignored  string `db:"-"`
+}
+
+func (tableTest) TableName() string {
+	return "table_test"
+}
+
+func TestNewTable(t *testing.T) {
+	table := NewTable(&tableTest{})
+	assert.Equal(t, "table_test", table.Name)
+	assert.Equal(t, []string{"tenant_id", "id", "title"}, table.Column)
+	assert.Equal(t, []string{"tenant_id", "id"}, table.PrimaryKey)
+
+	table = NewTable(Person{})
+	assert.Equal(t, "person", table.Name)
+	assert.Empty(t, table.PrimaryKey)
+	assert.Empty(t, table.DeletedAt)
+}
+
+type softDeleteTest struct {
+	ID        int64    `db:"id,pk"`
+	DeletedAt NullTime `db:"deleted_at,deleted_at"`
+}
+
+func TestTableScope(t *testing.T) {
+	table := NewTable(softDeleteTest{})
+	assert.Equal(t, "deleted_at", table.DeletedAt)
+
+	for _, test := range []struct {
+		table *Table
+		query string
+	}{
+		{
+			table: table,
+			query: "SELECT id FROM soft_delete_test WHERE (`deleted_at` IS NULL)",
+		},
+		{
+			table: table.WithDeleted(),
+			query: "SELECT id FROM soft_delete_test",
+		},
+		{
+			table: table.OnlyDeleted(),
+			query: "SELECT id FROM soft_delete_test WHERE (`deleted_at` IS NOT NULL)",
+		},
+	} {
+		stmt := &SelectBuilder{SelectStmt: Select("id").From(test.table.Name)}
+		buf := NewBuffer()
+		err := test.table.whereScope(stmt).Build(dialect.MySQL, buf)
+		assert.NoError(t, err)
+		assert.Equal(t, test.query, buf.String())
+	}
+
+	// scopes do not modify the original table
+	assert.Equal(t, withoutDeleted, table.scope)
+
+	assert.Nil(t, table.WithDeleted().scopeCond())
+	assert.Nil(t, NewTable(tableTest{}).scopeCond())
+}
+
+func TestTableWherePK(t *testing.T) {
+	table := NewTable(tableTest{})
+
+	_, err := table.wherePK([]interface{}{1})
+	assert.Equal(t, ErrPrimaryKeyCount, err)
+
+	_, err = NewTable(Person{}).wherePK([]interface{}{1})
+	assert.Equal(t, ErrPrimaryKeyNotFound, err)
+
+	cond, err := table.wherePK([]interface{}{1, 2})
+	assert.NoError(t, err)
+	buf := NewBuffer()
+	assert.NoError(t, cond.Build(dialect.MySQL, buf))
+	assert.Equal(t, "(`tenant_id` = ?) AND (`id` = ?)", buf.String())
+
+	assert.Equal(t, ErrRecordType, table.checkRecord(&Person{}))
+	assert.Equal(t, ErrInvalidPointer, table.checkRecord(tableTest{}))
+	assert.NoError(t, table.checkRecord(&tableTest{}))
+}
@@ -0,0 +1,170 @@
+package fjord
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"reflect"
+)
+
+// Tracked records column values loaded into a struct.
+// Embed it in a struct to update only modified columns with UpdateChanged.
+//
+//	type Suggestion struct {
+//		fjord.Tracked
+//		ID    int64  `db:"id,pk"`
+//		Title string `db:"title"`
+//	}
+type Tracked struct {
+	snapshot map[string]interface{}
+}
+
+func (t *Tracked) tracked() *Tracked {
+	return t
+}
+
+// tracker is a struct which embeds Tracked
+type tracker interface {
+	tracked() *Tracked
+}
+
+var typeTracked = reflect.TypeOf(Tracked{})
+
+// findTracked returns Tracked embedded in a struct, or nil.
+func findTracked(value reflect.Value) *Tracked {
+	if value.Kind() != reflect.Ptr {
+		if !value.CanAddr() {
+			return nil
+		}
+		value = value.Addr()
+	}
+	if value.IsNil() {
+		return nil
+	}
+	if t, ok := value.Interface().(tracker); ok {
+		return t.tracked()
+	}
+	return nil
+}
+
+// takeSnapshot records current column values of a struct which embeds Tracked.
+func takeSnapshot(value reflect.Value) {
+	t := findTracked(value)
+	if t == nil {
+		return
+	}
+	column, m := structFields(reflect.Indirect(value), true)
+	t.snapshot = make(map[string]interface{}, len(column))
+	for _, col := range column {
+		if field := m[col]; !field.isContainer() {
+			t.snapshot[col] = snapshotValue(field)
+		}
+	}
+}
+
+// snapshotValue copies a field value for comparison.
+// Pointers, slices and maps are copied so that changes in place are detected.
+func snapshotValue(field structField) interface{} {
+	if field.options.Contains("json") {
+		b, _ := json.Marshal(field.value.Interface())
+		return string(b)
+	}
+	return copyValue(field.value).Interface()
+}
+
+// copyValue returns a deep copy of pointers, slices and maps.
+func copyValue(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type().Elem())
+		c.Elem().Set(copyValue(v.Elem()))
+		return c
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			reflect.Copy(c, v)
+			return c
+		}
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(copyValue(v.Index(i)))
+		}
+		return c
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeMapWithSize(v.Type(), v.Len())
+		for _, key := range v.MapKeys() {
+			c.SetMapIndex(key, copyValue(v.MapIndex(key)))
+		}
+		return c
+	}
+	return v
+}
+
+// changedColumns returns updatable columns whose values differ from the snapshot.
+// When there is no snapshot, all updatable columns are returned.
+func changedColumns(value reflect.Value, t *Tracked) []string {
+	var changed []string
+	column, m := structFields(reflect.Indirect(value), true)
+	for _, col := range column {
+		field := m[col]
+		if field.isPK() || !field.updatable() || field.isContainer() {
+			continue
+		}
+		if t.snapshot != nil {
+			if old, ok := t.snapshot[col]; ok && reflect.DeepEqual(old, snapshotValue(field)) {
+				continue
+			}
+		}
+		changed = append(changed, col)
+	}
+	return changed
+}
+
+// UpdateChanged updates columns of a struct which were modified after it was loaded.
+// The struct must embed Tracked, and have a primary key tagged with pk.
+// When nothing has changed, no query is issued.
+func (sess *Session) UpdateChanged(table string, value interface{}) (sql.Result, error) {
+	return updateChanged(sess, table, value)
+}
+
+// UpdateChanged updates columns of a struct which were modified after it was loaded.
+func (tx *Tx) UpdateChanged(table string, value interface{}) (sql.Result, error) {
+	return updateChanged(tx, table, value)
+}
+
+func updateChanged(runner SessionRunner, table string, value interface{}) (sql.Result, error) {
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return nil, ErrInvalidPointer
+	}
+	t := findTracked(v)
+	if t == nil {
+		return nil, ErrNotTracked
+	}
+
+	// BeforeUpdate may modify the struct, so it is called before comparing
+	stmt := runner.Update(table)
+	if err := beforeUpdate(stmt.runner, value); err != nil {
+		return nil, err
+	}
+	changed := changedColumns(v, t)
+	if len(changed) == 0 {
+		return driver.RowsAffected(0), nil
+	}
+
+	stmt.UpdateStmt.SetRecord(value, changed...)
+	result, err := stmt.WherePK(value).Exec()
+	if err != nil {
+		return nil, err
+	}
+	takeSnapshot(v)
+	return result, nil
+}
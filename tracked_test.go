@@ -0,0 +1,37 @@
+package fjord
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type trackedTest struct {
+	Tracked
+	ID   int64    `db:"id,pk"`
+	Nick *string  `db:"nick"`
+	Tags []string `db:"tags"`
+	Data []byte   `db:"data"`
+}
+
+func TestChangedColumnsInPlace(t *testing.T) {
+	nick := "a"
+	record := &trackedTest{ID: 1, Nick: &nick, Tags: []string{"a"}, Data: []byte("a")}
+	v := reflect.ValueOf(record)
+	takeSnapshot(v)
+	assert.Empty(t, changedColumns(v, &record.Tracked))
+
+	*record.Nick = "b"
+	assert.Equal(t, []string{"nick"}, changedColumns(v, &record.Tracked))
+
+	takeSnapshot(v)
+	record.Tags[0] = "b"
+	record.Data[0] = 'b'
+	assert.Equal(t, []string{"tags", "data"}, changedColumns(v, &record.Tracked))
+
+	takeSnapshot(v)
+	record.Nick = nil
+	record.Tags = nil
+	assert.Equal(t, []string{"nick", "tags"}, changedColumns(v, &record.Tracked))
+}
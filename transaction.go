@@ -3,12 +3,16 @@ package fjord
 import (
 	"context"
 	"database/sql"
+	"time"
 )
 
 // Tx is a transaction for the given Session
 type Tx struct {
 	EventReceiver
-	Dialect Dialect
+	Dialect      Dialect
+	StrictMode   StrictMode
+	Clock        func() time.Time
+	RequireWhere bool
 	*sql.Tx
 	ctx context.Context
 }
@@ -24,6 +28,9 @@ func (sess *Session) BeginTx(options *sql.TxOptions) (*Tx, error) {
 	return &Tx{
 		EventReceiver: sess,
 		Dialect:       sess.Dialect,
+		StrictMode:    sess.StrictMode,
+		Clock:         sess.Clock,
+		RequireWhere:  sess.RequireWhere,
 		Tx:            tx,
 		ctx:           sess.ctx,
 	}, nil
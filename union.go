@@ -1,33 +1,79 @@
 package fjord
 
-type union struct {
-	builder []Builder
-	all     bool
+import (
+	"fmt"
+
+	"github.com/iktakahiro/fjord/dialect"
+)
+
+// CompoundStmt builds `UNION`, `INTERSECT` or `EXCEPT` of select statements.
+// ORDER BY, LIMIT and OFFSET are applied to the whole result.
+type CompoundStmt struct {
+	builder  []Builder
+	operator string
+	all      bool
+
+	Order []Builder
+
+	LimitCount  int64
+	OffsetCount int64
 }
 
-func Union(builder ...Builder) interface {
-	Builder
-	As(string) Builder
-} {
-	return &union{
-		builder: builder,
+func compound(operator string, all bool, builder []Builder) *CompoundStmt {
+	return &CompoundStmt{
+		builder:     builder,
+		operator:    operator,
+		all:         all,
+		LimitCount:  -1,
+		OffsetCount: -1,
 	}
 }
 
-func UnionAll(builder ...Builder) interface {
-	Builder
-	As(string) Builder
-} {
-	return &union{
-		builder: builder,
-		all:     true,
-	}
+// Union creates a CompoundStmt with `UNION`
+func Union(builder ...Builder) *CompoundStmt {
+	return compound("UNION", false, builder)
+}
+
+// UnionAll creates a CompoundStmt with `UNION ALL`
+func UnionAll(builder ...Builder) *CompoundStmt {
+	return compound("UNION", true, builder)
+}
+
+// Intersect creates a CompoundStmt with `INTERSECT`.
+// It is not supported by MySQL.
+func Intersect(builder ...Builder) *CompoundStmt {
+	return compound("INTERSECT", false, builder)
 }
 
-func (u *union) Build(d Dialect, buf Buffer) error {
+// IntersectAll creates a CompoundStmt with `INTERSECT ALL`.
+// It is not supported by MySQL.
+func IntersectAll(builder ...Builder) *CompoundStmt {
+	return compound("INTERSECT", true, builder)
+}
+
+// Except creates a CompoundStmt with `EXCEPT`.
+// It is not supported by MySQL.
+func Except(builder ...Builder) *CompoundStmt {
+	return compound("EXCEPT", false, builder)
+}
+
+// ExceptAll creates a CompoundStmt with `EXCEPT ALL`.
+// It is not supported by MySQL.
+func ExceptAll(builder ...Builder) *CompoundStmt {
+	return compound("EXCEPT", true, builder)
+}
+
+// Build builds the compound statement in dialect
+func (u *CompoundStmt) Build(d Dialect, buf Buffer) error {
+	if u.operator != "UNION" && d == dialect.MySQL {
+		return ErrNotSupported
+	}
+
 	for i, b := range u.builder {
 		if i > 0 {
-			buf.WriteString(" UNION ")
+			buf.WriteString(" ")
+			buf.WriteString(u.operator)
+			buf.WriteString(" ")
 			if u.all {
 				buf.WriteString("ALL ")
 			}
@@ -35,9 +81,63 @@ func (u *union) Build(d Dialect, buf Buffer) error {
 		buf.WriteString(placeholder)
 		buf.WriteValue(b)
 	}
+
+	if len(u.Order) > 0 {
+		buf.WriteString(" ORDER BY ")
+		for i, order := range u.Order {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			err := order.Build(d, buf)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if u.LimitCount >= 0 {
+		buf.WriteString(" LIMIT ")
+		buf.WriteString(fmt.Sprint(u.LimitCount))
+	}
+
+	if u.OffsetCount >= 0 {
+		buf.WriteString(" OFFSET ")
+		buf.WriteString(fmt.Sprint(u.OffsetCount))
+	}
 	return nil
 }
 
-func (u *union) As(alias string) Builder {
+// OrderBy specifies an expression for ordering the whole result
+func (u *CompoundStmt) OrderBy(col string) *CompoundStmt {
+	u.Order = append(u.Order, Expr(col))
+	return u
+}
+
+// OrderAsc specifies columns for ordering the whole result
+func (u *CompoundStmt) OrderAsc(col string) *CompoundStmt {
+	u.Order = append(u.Order, order(col, asc))
+	return u
+}
+
+// OrderDesc specifies columns for ordering the whole result
+func (u *CompoundStmt) OrderDesc(col string) *CompoundStmt {
+	u.Order = append(u.Order, order(col, desc))
+	return u
+}
+
+// Limit adds `LIMIT` to the whole result
+func (u *CompoundStmt) Limit(n uint64) *CompoundStmt {
+	u.LimitCount = int64(n)
+	return u
+}
+
+// Offset adds `OFFSET` to the whole result
+func (u *CompoundStmt) Offset(n uint64) *CompoundStmt {
+	u.OffsetCount = int64(n)
+	return u
+}
+
+// As creates alias for the compound statement
+func (u *CompoundStmt) As(alias string) Builder {
 	return as(u, alias)
 }
@@ -0,0 +1,68 @@
+package fjord
+
+// CompoundBuilder loads the result of a CompoundStmt
+type CompoundBuilder struct {
+	runner
+	EventReceiver
+	Dialect Dialect
+
+	*CompoundStmt
+
+	StrictMode StrictMode
+}
+
+// Compound creates a CompoundBuilder to load the result of stmt
+func (sess *Session) Compound(stmt *CompoundStmt) *CompoundBuilder {
+	return &CompoundBuilder{
+		runner:        sess,
+		EventReceiver: sess,
+		Dialect:       sess.Dialect,
+		CompoundStmt:  stmt,
+		StrictMode:    sess.StrictMode,
+	}
+}
+
+// Compound creates a CompoundBuilder to load the result of stmt
+func (tx *Tx) Compound(stmt *CompoundStmt) *CompoundBuilder {
+	return &CompoundBuilder{
+		runner:        tx,
+		EventReceiver: tx,
+		Dialect:       tx.Dialect,
+		CompoundStmt:  stmt,
+		StrictMode:    tx.StrictMode,
+	}
+}
+
+func (b *CompoundBuilder) Load(value interface{}) (int, error) {
+	return query(b.runner, b.EventReceiver, b, b.Dialect, value, b.StrictMode)
+}
+
+// Strict sets the strict mapping mode used by Load
+func (b *CompoundBuilder) Strict(mode StrictMode) *CompoundBuilder {
+	b.StrictMode = mode
+	return b
+}
+
+func (b *CompoundBuilder) OrderBy(col string) *CompoundBuilder {
+	b.CompoundStmt.OrderBy(col)
+	return b
+}
+
+func (b *CompoundBuilder) OrderDir(col string, isAsc bool) *CompoundBuilder {
+	if isAsc {
+		b.CompoundStmt.OrderAsc(col)
+	} else {
+		b.CompoundStmt.OrderDesc(col)
+	}
+	return b
+}
+
+func (b *CompoundBuilder) Limit(n uint64) *CompoundBuilder {
+	b.CompoundStmt.Limit(n)
+	return b
+}
+
+func (b *CompoundBuilder) Offset(n uint64) *CompoundBuilder {
+	b.CompoundStmt.Offset(n)
+	return b
+}
@@ -0,0 +1,73 @@
+package fjord
+
+import (
+	"testing"
+
+	"github.com/iktakahiro/fjord/dialect"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompoundStmt(t *testing.T) {
+	for _, test := range []struct {
+		stmt  *CompoundStmt
+		d     Dialect
+		query string
+	}{
+		{
+			stmt:  Union(Select("a").From("t1"), Select("a").From("t2")),
+			d:     dialect.MySQL,
+			query: "(SELECT a FROM t1) UNION (SELECT a FROM t2)",
+		},
+		{
+			stmt:  UnionAll(Select("a").From("t1"), Select("a").From("t2")).OrderDesc("a").Limit(10).Offset(20),
+			d:     dialect.MySQL,
+			query: "(SELECT a FROM t1) UNION ALL (SELECT a FROM t2) ORDER BY a DESC LIMIT 10 OFFSET 20",
+		},
+		{
+			stmt:  Intersect(Select("a").From("t1"), Select("a").From("t2").Where(Eq("b", 1))),
+			d:     dialect.PostgreSQL,
+			query: `(SELECT a FROM t1) INTERSECT (SELECT a FROM t2 WHERE ("b" = 1))`,
+		},
+		{
+			stmt:  IntersectAll(Select("a").From("t1"), Select("a").From("t2")),
+			d:     dialect.PostgreSQL,
+			query: "(SELECT a FROM t1) INTERSECT ALL (SELECT a FROM t2)",
+		},
+		{
+			stmt:  Except(Select("a").From("t1"), Select("a").From("t2")).OrderBy("1"),
+			d:     dialect.PostgreSQL,
+			query: "(SELECT a FROM t1) EXCEPT (SELECT a FROM t2) ORDER BY 1",
+		},
+		{
+			stmt:  ExceptAll(Select("a").From("t1"), Select("a").From("t2")).Limit(1),
+			d:     dialect.PostgreSQL,
+			query: "(SELECT a FROM t1) EXCEPT ALL (SELECT a FROM t2) LIMIT 1",
+		},
+	} {
+		buf := NewBuffer()
+		err := test.stmt.Build(test.d, buf)
+		assert.NoError(t, err)
+		query, err := InterpolateForDialect(buf.String(), buf.Value(), test.d)
+		assert.NoError(t, err)
+		assert.Equal(t, test.query, query)
+	}
+}
+
+func TestCompoundStmtNotSupported(t *testing.T) {
+	for _, stmt := range []*CompoundStmt{
+		Intersect(Select("a").From("t1"), Select("a").From("t2")),
+		ExceptAll(Select("a").From("t1"), Select("a").From("t2")),
+	} {
+		err := stmt.Build(dialect.MySQL, NewBuffer())
+		assert.Equal(t, ErrNotSupported, err)
+	}
+}
+
+func TestCompoundStmtAs(t *testing.T) {
+	stmt := Select("count(*)").From(
+		Union(Select("a").From("t1"), Select("a").From("t2")).Limit(5).As("u"),
+	)
+	query, err := InterpolateForDialect("?", []interface{}{stmt}, dialect.PostgreSQL)
+	assert.NoError(t, err)
+	assert.Equal(t, `(SELECT count(*) FROM ((SELECT a FROM t1) UNION (SELECT a FROM t2) LIMIT 5) AS "u")`, query)
+}
@@ -1,5 +1,14 @@
 package fjord
 
+import (
+	"database/sql"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/iktakahiro/fjord/dialect"
+)
+
 // UpdateStmt builds `UPDATE ...`
 type UpdateStmt struct {
 	raw
@@ -8,6 +17,15 @@ type UpdateStmt struct {
 	Value map[string]interface{}
 
 	WhereCond []Builder
+
+	joinTable []joinTable
+
+	// Clock returns the time for fields tagged with autoupdate.
+	// When it is nil, NOW() of the database is used.
+	Clock func() time.Time
+
+	// version is the field tagged with version, set by SetRecord
+	version reflect.Value
 }
 
 // Build builds `UPDATE ...` in dialect
@@ -26,24 +44,47 @@ func (b *UpdateStmt) Build(d Dialect, buf Buffer) error {
 
 	buf.WriteString("UPDATE ")
 	buf.WriteString(d.QuoteIdent(b.Table))
+	if len(b.joinTable) > 0 {
+		switch d {
+		case dialect.MySQL:
+			if err := buildJoinTables(d, buf, b.joinTable); err != nil {
+				return err
+			}
+		case dialect.PostgreSQL:
+			// written as `FROM` after `SET`
+		default:
+			return ErrNotSupported
+		}
+	}
 	buf.WriteString(" SET ")
 
-	i := 0
-	for col, v := range b.Value {
+	// sort columns to build the same query every time
+	column := make([]string, 0, len(b.Value))
+	for col := range b.Value {
+		column = append(column, col)
+	}
+	sort.Strings(column)
+
+	for i, col := range column {
 		if i > 0 {
 			buf.WriteString(", ")
 		}
 		buf.WriteString(d.QuoteIdent(col))
 		buf.WriteString(" = ")
+		// builders are interpolated in place, and subqueries are parenthesized
 		buf.WriteString(placeholder)
+		buf.WriteValue(b.Value[col])
+	}
 
-		buf.WriteValue(v)
-		i++
+	cond := b.WhereCond
+	if len(b.joinTable) > 0 && d == dialect.PostgreSQL {
+		buf.WriteString(" FROM ")
+		cond = append(buildTableList(d, buf, b.joinTable), cond...)
 	}
 
-	if len(b.WhereCond) > 0 {
+	if len(cond) > 0 {
 		buf.WriteString(" WHERE ")
-		err := And(b.WhereCond...).Build(d, buf)
+		err := And(cond...).Build(d, buf)
 		if err != nil {
 			return err
 		}
@@ -81,12 +122,40 @@ func (b *UpdateStmt) Where(query interface{}, value ...interface{}) *UpdateStmt
 	return b
 }
 
+// Join joins a table to update rows which match another table.
+// It is `UPDATE a JOIN b ON ... SET ...` in MySQL,
+// and `UPDATE a SET ... FROM b WHERE ...` in PostgreSQL.
+func (b *UpdateStmt) Join(table, on interface{}) *UpdateStmt {
+	b.joinTable = append(b.joinTable, joinTable{table: table, on: on})
+	return b
+}
+
 // Set specifies a key-value pair
 func (b *UpdateStmt) Set(column string, value interface{}) *UpdateStmt {
 	b.Value[column] = value
 	return b
 }
 
+// SetExpr sets an expression to a column. e.g. SetExpr("name", "UPPER(name)")
+func (b *UpdateStmt) SetExpr(column, query string, value ...interface{}) *UpdateStmt {
+	return b.Set(column, Expr(query, value...))
+}
+
+// SetSelect sets the result of a scalar subquery to a column.
+func (b *UpdateStmt) SetSelect(column string, stmt *SelectStmt) *UpdateStmt {
+	return b.Set(column, stmt)
+}
+
+// Increment adds n to a column. e.g. `count = count + 1`
+func (b *UpdateStmt) Increment(column string, n interface{}) *UpdateStmt {
+	return b.Set(column, Expr("? + ?", I(column), n))
+}
+
+// Decrement subtracts n from a column. e.g. `count = count - 1`
+func (b *UpdateStmt) Decrement(column string, n interface{}) *UpdateStmt {
+	return b.Set(column, Expr("? - ?", I(column), n))
+}
+
 // SetMap specifies a list of key-value pair
 func (b *UpdateStmt) SetMap(m map[string]interface{}) *UpdateStmt {
 	for col, val := range m {
@@ -94,3 +163,81 @@ func (b *UpdateStmt) SetMap(m map[string]interface{}) *UpdateStmt {
 	}
 	return b
 }
+
+// SetRecord specifies key-value pairs from a struct.
+// When no column is given, all columns except the primary key and
+// fields tagged with readonly, -update or autocreate are set.
+// A column which is not found in the struct is ignored.
+// Fields tagged with autoupdate are always set to the current time.
+//
+// A field tagged with version is used for optimistic locking.
+// e.g. `db:"version,version"`
+// It adds `WHERE version = ?` and `SET version = version + 1`.
+func (b *UpdateStmt) SetRecord(structValue interface{}, column ...string) *UpdateStmt {
+	v := reflect.Indirect(reflect.ValueOf(structValue))
+	if v.Kind() != reflect.Struct {
+		return b
+	}
+
+	all, m := structFields(v, true)
+	if len(column) == 0 {
+		for _, col := range all {
+			field := m[col]
+			if field.isPK() || !field.updatable() || field.isContainer() {
+				continue
+			}
+			column = append(column, col)
+		}
+	}
+	for _, col := range column {
+		if field, ok := m[col]; ok {
+			b.Set(col, field.updateValue(b.Clock))
+		}
+	}
+	for _, col := range all {
+		field := m[col]
+		if field.isAutoUpdate() {
+			if _, ok := b.Value[col]; !ok {
+				b.Set(col, field.updateValue(b.Clock))
+			}
+		}
+		if field.isVersion() && !b.version.IsValid() {
+			b.Increment(col, 1)
+			b.Where(Eq(col, field.value.Interface()))
+			b.version = field.value
+		}
+	}
+	return b
+}
+
+// checkVersion returns ErrStaleObject when no row is updated by optimistic locking.
+// Otherwise, it increments the version of the struct.
+func (b *UpdateStmt) checkVersion(result sql.Result) error {
+	if !b.version.IsValid() {
+		return nil
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrStaleObject
+	}
+	if b.version.CanSet() {
+		switch b.version.Kind() {
+		case reflect.Int, reflect.Int32, reflect.Int64:
+			b.version.SetInt(b.version.Int() + 1)
+		default:
+			b.version.SetUint(b.version.Uint() + 1)
+		}
+	}
+	return nil
+}
+
+// WherePK adds where conditions on the primary key of a struct, which is
+// fields tagged with pk. e.g. `db:"id,pk"`
+// When there is no primary key, Build returns ErrPrimaryKeyNotFound.
+func (b *UpdateStmt) WherePK(structValue interface{}) *UpdateStmt {
+	b.WhereCond = append(b.WhereCond, wherePK(structValue))
+	return b
+}
@@ -13,25 +13,37 @@ type UpdateBuilder struct {
 	*UpdateStmt
 
 	LimitCount int64
+
+	// RequireWhere makes Exec fail without WHERE unless IsAllRows is set
+	RequireWhere bool
+	IsAllRows    bool
+
+	err error
 }
 
 func (sess *Session) Update(table string) *UpdateBuilder {
+	stmt := Update(table)
+	stmt.Clock = sess.Clock
 	return &UpdateBuilder{
 		runner:        sess,
 		EventReceiver: sess,
 		Dialect:       sess.Dialect,
-		UpdateStmt:    Update(table),
+		UpdateStmt:    stmt,
 		LimitCount:    -1,
+		RequireWhere:  sess.RequireWhere,
 	}
 }
 
 func (tx *Tx) Update(table string) *UpdateBuilder {
+	stmt := Update(table)
+	stmt.Clock = tx.Clock
 	return &UpdateBuilder{
 		runner:        tx,
 		EventReceiver: tx,
 		Dialect:       tx.Dialect,
-		UpdateStmt:    Update(table),
+		UpdateStmt:    stmt,
 		LimitCount:    -1,
+		RequireWhere:  tx.RequireWhere,
 	}
 }
 
@@ -42,6 +54,7 @@ func (sess *Session) UpdateBySql(query string, value ...interface{}) *UpdateBuil
 		Dialect:       sess.Dialect,
 		UpdateStmt:    UpdateBySql(query, value...),
 		LimitCount:    -1,
+		RequireWhere:  sess.RequireWhere,
 	}
 }
 
@@ -52,11 +65,41 @@ func (tx *Tx) UpdateBySql(query string, value ...interface{}) *UpdateBuilder {
 		Dialect:       tx.Dialect,
 		UpdateStmt:    UpdateBySql(query, value...),
 		LimitCount:    -1,
+		RequireWhere:  tx.RequireWhere,
 	}
 }
 
 func (b *UpdateBuilder) Exec() (sql.Result, error) {
-	return exec(b.runner, b.EventReceiver, b, b.Dialect)
+	if b.err != nil {
+		return nil, b.EventErrKv("fjord.update.hook", b.err, kvs{
+			"table": b.Table,
+		})
+	}
+	if b.unbounded() {
+		return nil, b.EventErrKv("fjord.update.unbounded", ErrMissingWhere, kvs{
+			"table": b.Table,
+		})
+	}
+
+	result, err := exec(b.runner, b.EventReceiver, b, b.Dialect)
+	if err != nil {
+		return result, err
+	}
+	if err := b.checkVersion(result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// AllRows allows Exec without WHERE when RequireWhere is set.
+func (b *UpdateBuilder) AllRows() *UpdateBuilder {
+	b.IsAllRows = true
+	return b
+}
+
+// unbounded reports whether the statement would update all rows against RequireWhere
+func (b *UpdateBuilder) unbounded() bool {
+	return b.RequireWhere && !b.IsAllRows && b.raw.Query == "" && len(b.WhereCond) == 0
 }
 
 func (b *UpdateBuilder) Set(column string, value interface{}) *UpdateBuilder {
@@ -64,11 +107,51 @@ func (b *UpdateBuilder) Set(column string, value interface{}) *UpdateBuilder {
 	return b
 }
 
+func (b *UpdateBuilder) SetExpr(column, query string, value ...interface{}) *UpdateBuilder {
+	b.UpdateStmt.SetExpr(column, query, value...)
+	return b
+}
+
+func (b *UpdateBuilder) SetSelect(column string, stmt *SelectStmt) *UpdateBuilder {
+	b.UpdateStmt.SetSelect(column, stmt)
+	return b
+}
+
+func (b *UpdateBuilder) Increment(column string, n interface{}) *UpdateBuilder {
+	b.UpdateStmt.Increment(column, n)
+	return b
+}
+
+func (b *UpdateBuilder) Decrement(column string, n interface{}) *UpdateBuilder {
+	b.UpdateStmt.Decrement(column, n)
+	return b
+}
+
 func (b *UpdateBuilder) SetMap(m map[string]interface{}) *UpdateBuilder {
 	b.UpdateStmt.SetMap(m)
 	return b
 }
 
+// SetRecord specifies key-value pairs from a struct.
+// BeforeUpdate of the struct is called before values are read.
+func (b *UpdateBuilder) SetRecord(structValue interface{}, column ...string) *UpdateBuilder {
+	if err := beforeUpdate(b.runner, structValue); err != nil && b.err == nil {
+		b.err = err
+	}
+	b.UpdateStmt.SetRecord(structValue, column...)
+	return b
+}
+
+func (b *UpdateBuilder) WherePK(structValue interface{}) *UpdateBuilder {
+	b.UpdateStmt.WherePK(structValue)
+	return b
+}
+
+func (b *UpdateBuilder) Join(table, on interface{}) *UpdateBuilder {
+	b.UpdateStmt.Join(table, on)
+	return b
+}
+
 func (b *UpdateBuilder) Where(query interface{}, value ...interface{}) *UpdateBuilder {
 	b.UpdateStmt.Where(query, value...)
 	return b
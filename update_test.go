@@ -1,7 +1,9 @@
 package fjord
 
 import (
+	"database/sql/driver"
 	"testing"
+	"time"
 
 	"github.com/iktakahiro/fjord/dialect"
 	"github.com/stretchr/testify/assert"
@@ -17,6 +19,70 @@ func TestUpdateStmt(t *testing.T) {
 	assert.Equal(t, []interface{}{1, 2}, buf.Value())
 }
 
+type updateRecordTest struct {
+	ID        int64             `db:"id,pk"`
+	Title     string            `db:"title"`
+	Body      string            `db:"body"`
+	Payload   map[string]string `db:"payload,json"`
+	Score     int               `db:"score,readonly"`
+	CreatedBy string            `db:"created_by,-update"`
+}
+
+func TestUpdateStmtSetRecord(t *testing.T) {
+	record := &updateRecordTest{
+		ID:        1,
+		Title:     "title",
+		Body:      "body",
+		Payload:   map[string]string{"a": "b"},
+		Score:     2,
+		CreatedBy: "admin",
+	}
+
+	buf := NewBuffer()
+	builder := Update("table").SetRecord(record).WherePK(record)
+	err := builder.Build(dialect.MySQL, buf)
+	assert.NoError(t, err)
+
+	query, err := InterpolateForDialect(buf.String(), buf.Value(), dialect.MySQL)
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE `table` SET `body` = 'body', `payload` = '{\\\"a\\\":\\\"b\\\"}', `title` = 'title' WHERE ((`id` = 1))", query)
+
+	buf = NewBuffer()
+	builder = Update("table").SetRecord(record, "title", "unknown").WherePK(record)
+	err = builder.Build(dialect.MySQL, buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE `table` SET `title` = ? WHERE ((`id` = ?))", buf.String())
+	assert.Equal(t, []interface{}{"title", int64(1)}, buf.Value())
+}
+
+func TestUpdateStmtWherePKNotFound(t *testing.T) {
+	buf := NewBuffer()
+	builder := Update("table").Set("a", 1).WherePK(&insertTest{A: 1})
+	err := builder.Build(dialect.MySQL, buf)
+	assert.Equal(t, ErrPrimaryKeyNotFound, err)
+}
+
+func TestUpdateStmtSetRecordTimestamp(t *testing.T) {
+	now := time.Date(2017, 4, 1, 12, 0, 0, 0, time.UTC)
+	record := &insertTimestampTest{Name: "one"}
+
+	buf := NewBuffer()
+	builder := Update("table")
+	builder.Clock = func() time.Time { return now }
+	err := builder.SetRecord(record, "name").Build(dialect.MySQL, buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE `table` SET `name` = ?, `updated_at` = ?", buf.String())
+	assert.Equal(t, []interface{}{"one", NullTime{Time: now, Valid: true}}, buf.Value())
+	assert.True(t, record.CreatedAt.IsZero())
+
+	buf = NewBuffer()
+	err = Update("table").SetRecord(record).Build(dialect.MySQL, buf)
+	assert.NoError(t, err)
+	query, err := InterpolateForDialect(buf.String(), buf.Value(), dialect.MySQL)
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE `table` SET `name` = 'one', `updated_at` = NOW()", query)
+}
+
 func BenchmarkUpdateValuesSQL(b *testing.B) {
 	buf := NewBuffer()
 	for i := 0; i < b.N; i++ {
@@ -30,3 +96,122 @@ func BenchmarkUpdateMapSQL(b *testing.B) {
 		Update("table").SetMap(map[string]interface{}{"a": 1, "b": 2}).Build(dialect.MySQL, buf)
 	}
 }
+
+type updateVersionTest struct {
+	ID      int64  `db:"id,pk"`
+	Title   string `db:"title"`
+	Version int    `db:"version,version"`
+}
+
+func TestUpdateStmtSetRecordVersion(t *testing.T) {
+	record := &updateVersionTest{ID: 1, Title: "title", Version: 3}
+
+	stmt := Update("table").SetRecord(record).WherePK(record)
+	query, err := InterpolateForDialect("?", []interface{}{stmt}, dialect.MySQL)
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE `table` SET `title` = 'title', `version` = `version` + 1 WHERE (`version` = 3) AND ((`id` = 1))", query)
+
+	assert.Equal(t, ErrStaleObject, stmt.checkVersion(driver.RowsAffected(0)))
+	assert.Equal(t, 3, record.Version)
+
+	assert.NoError(t, stmt.checkVersion(driver.RowsAffected(1)))
+	assert.Equal(t, 4, record.Version)
+}
+
+func TestUpdateStmtJoin(t *testing.T) {
+	for _, test := range []struct {
+		d     Dialect
+		query string
+	}{
+		{
+			d: dialect.MySQL,
+			query: "UPDATE `person` JOIN `role` ON person.id = role.person_id SET `name` = 'admin' " +
+				"WHERE (`role`.`name` = 'admin')",
+		},
+		{
+			d: dialect.PostgreSQL,
+			query: `UPDATE "person" SET "name" = 'admin' FROM "role" ` +
+				`WHERE (person.id = role.person_id) AND ("role"."name" = 'admin')`,
+		},
+	} {
+		stmt := Update("person").
+			Join("role", "person.id = role.person_id").
+			Set("name", "admin").
+			Where(Eq("role.name", "admin"))
+		query, err := InterpolateForDialect("?", []interface{}{stmt}, test.d)
+		assert.NoError(t, err)
+		assert.Equal(t, test.query, query)
+	}
+}
+
+func TestUpdateStmtSetExpr(t *testing.T) {
+	for _, test := range []struct {
+		d     Dialect
+		query string
+	}{
+		{
+			d: dialect.MySQL,
+			query: "UPDATE `person` SET `count` = `count` + 2, `name` = UPPER(name), " +
+				"`role_count` = (SELECT COUNT(*) FROM role WHERE (person_id = person.id)), " +
+				"`score` = `score` - 1.5, `title` = CONCAT('Dr. ', name) WHERE (`id` = 1)",
+		},
+		{
+			d: dialect.PostgreSQL,
+			query: `UPDATE "person" SET "count" = "count" + 2, "name" = UPPER(name), ` +
+				`"role_count" = (SELECT COUNT(*) FROM role WHERE (person_id = person.id)), ` +
+				`"score" = "score" - 1.5, "title" = CONCAT('Dr. ', name) WHERE ("id" = 1)`,
+		},
+	} {
+		stmt := Update("person").
+			Increment("count", 2).
+			Decrement("score", 1.5).
+			SetExpr("name", "UPPER(name)").
+			SetExpr("title", "CONCAT(?, name)", "Dr. ").
+			SetSelect("role_count", Select("COUNT(*)").From("role").Where("person_id = person.id")).
+			Where(Eq("id", 1))
+		query, err := InterpolateForDialect("?", []interface{}{stmt}, test.d)
+		assert.NoError(t, err)
+		assert.Equal(t, test.query, query)
+	}
+}
+
+func TestUpdateStmtSetCompound(t *testing.T) {
+	stmt := Update("person").
+		Set("name", Union(
+			Select("name").From("person2").Where(Eq("id", 1)),
+			Select("name").From("person3").Where(Eq("id", 1)),
+		).Limit(1)).
+		Where(Eq("id", 1))
+	query, err := InterpolateForDialect("?", []interface{}{stmt}, dialect.MySQL)
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE `person` SET `name` = ((SELECT name FROM person2 WHERE (`id` = 1)) "+
+		"UNION (SELECT name FROM person3 WHERE (`id` = 1)) LIMIT 1) WHERE (`id` = 1)", query)
+}
+
+// errorEventRecorder records names of error events
+type errorEventRecorder struct {
+	NullEventReceiver
+	event []string
+}
+
+func (r *errorEventRecorder) EventErrKv(eventName string, err error, kvs map[string]string) error {
+	r.event = append(r.event, eventName)
+	return err
+}
+
+func TestUpdateBuilderRequireWhere(t *testing.T) {
+	log := &errorEventRecorder{}
+	sess := (&Connection{Dialect: dialect.MySQL, EventReceiver: log, RequireWhere: true}).NewSession(nil)
+
+	_, err := sess.Update("table").Set("a", 1).Exec()
+	assert.Equal(t, ErrMissingWhere, err)
+	assert.Equal(t, []string{"fjord.update.unbounded"}, log.event)
+
+	assert.True(t, sess.Update("table").Set("a", 1).unbounded())
+	assert.False(t, sess.Update("table").Set("a", 1).Where(Eq("b", 2)).unbounded())
+	assert.False(t, sess.Update("table").Set("a", 1).AllRows().unbounded())
+	assert.False(t, sess.UpdateBySql("UPDATE `table` SET a = 1").unbounded())
+
+	sess.RequireWhere = false
+	assert.False(t, sess.Update("table").Set("a", 1).unbounded())
+}
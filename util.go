@@ -5,6 +5,8 @@ import (
 	"database/sql/driver"
 	"reflect"
 	"strings"
+	"sync"
+	"time"
 	"unicode"
 )
 
@@ -36,7 +38,7 @@ func camelCaseToSnakeCase(name string) string {
 
 // getColumnNameFromTag get a value from the db tag in a Struct field.
 func getColumnNameFromTag(field reflect.StructField, ignorePrefix bool) (column string) {
-	tag := field.Tag.Get("db")
+	tag, _ := parseTag(field.Tag.Get("db"))
 	if tag == "-" {
 		// Ignore the field that "-" tag is set.
 		return ""
@@ -55,6 +57,182 @@ func getColumnNameFromTag(field reflect.StructField, ignorePrefix bool) (column
 	return tag
 }
 
+// tagOptions is the string following a comma in a db tag.
+// e.g. `db:"payload,json"` => "json"
+type tagOptions string
+
+// parseTag splits a db tag into the column name and options.
+func parseTag(tag string) (string, tagOptions) {
+	if i := strings.Index(tag, ","); i != -1 {
+		return tag[:i], tagOptions(tag[i+1:])
+	}
+	return tag, ""
+}
+
+// Contains reports whether a comma-separated list of options contains the option.
+func (o tagOptions) Contains(name string) bool {
+	s := string(o)
+	for s != "" {
+		var next string
+		if i := strings.Index(s, ","); i >= 0 {
+			s, next = s[:i], s[i+1:]
+		}
+		if s == name {
+			return true
+		}
+		s = next
+	}
+	return false
+}
+
+// structField is a struct field mapped to a column
+type structField struct {
+	value   reflect.Value
+	options tagOptions
+}
+
+// ptr returns a destination to scan a column into the field.
+func (f structField) ptr() interface{} {
+	if f.options.Contains("json") {
+		return jsonScanner{dest: f.value.Addr().Interface()}
+	}
+	return f.value.Addr().Interface()
+}
+
+// Interface returns a value to write into the column.
+func (f structField) Interface() interface{} {
+	if f.options.Contains("json") {
+		return JSON(f.value.Interface())
+	}
+	return f.value.Interface()
+}
+
+// insertValue returns a value to write into the column by INSERT.
+// Read-only fields, and empty fields with omitempty are left to the database default.
+// Timestamp fields are set to the current time only when they are zero, so that
+// preset values are kept.
+func (f structField) insertValue(clock func() time.Time) interface{} {
+	if f.options.Contains("readonly") || f.options.Contains("-insert") {
+		return Default
+	}
+	if (f.options.Contains("autocreate") || f.options.Contains("autoupdate")) && isZero(f.value) {
+		return f.touch(clock)
+	}
+	if f.options.Contains("omitempty") && isZero(f.value) {
+		return Default
+	}
+	return f.Interface()
+}
+
+// updateValue returns a value to write into the column by UPDATE.
+func (f structField) updateValue(clock func() time.Time) interface{} {
+	if f.isAutoUpdate() {
+		return f.touch(clock)
+	}
+	return f.Interface()
+}
+
+// touch sets the current time to a field tagged with autocreate or autoupdate,
+// and returns a value to write. Without clock, NOW() of the database is written,
+// except for integer fields which have UNIX time.
+func (f structField) touch(clock func() time.Time) interface{} {
+	t := f.value.Type()
+	isInt := false
+	switch t.Kind() {
+	case reflect.Int, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		isInt = true
+	}
+	if clock == nil {
+		if !isInt {
+			return databaseNow
+		}
+		clock = time.Now
+	}
+
+	now := clock()
+	var value reflect.Value
+	switch {
+	case t == typeTime:
+		value = reflect.ValueOf(now)
+	case t == reflect.PtrTo(typeTime):
+		value = reflect.ValueOf(&now)
+	case t == typeNullTime:
+		value = reflect.ValueOf(NullTime{Time: now, Valid: true})
+	case isInt:
+		value = reflect.ValueOf(now.Unix()).Convert(t)
+	default:
+		return now
+	}
+	if f.value.CanSet() {
+		f.value.Set(value)
+	}
+	return value.Interface()
+}
+
+// isPK reports whether the field is a part of the primary key.
+func (f structField) isPK() bool {
+	return f.options.Contains("pk")
+}
+
+// updatable reports whether the field is written by UPDATE.
+func (f structField) updatable() bool {
+	return !f.options.Contains("readonly") && !f.options.Contains("-update") &&
+		!f.options.Contains("autocreate") && !f.options.Contains("deleted_at") &&
+		!f.options.Contains("version")
+}
+
+// isVersion reports whether the field is a version for optimistic locking.
+func (f structField) isVersion() bool {
+	switch f.value.Kind() {
+	case reflect.Int, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return f.options.Contains("version")
+	}
+	return false
+}
+
+// isAutoUpdate reports whether the field is set to the current time by UPDATE.
+func (f structField) isAutoUpdate() bool {
+	return f.options.Contains("autoupdate") && f.updatable()
+}
+
+// isContainer reports whether the field only groups other mapped fields.
+func (f structField) isContainer() bool {
+	return !f.options.Contains("json") && isContainer(f.value)
+}
+
+// structFieldMap is same as structMap, but values have tag options.
+func structFieldMap(value reflect.Value, ignorePrefix bool) map[string]structField {
+	_, m := structFields(value, ignorePrefix)
+	return m
+}
+
+// structFields returns columns in field order, and fields mapped to them.
+func structFields(value reflect.Value, ignorePrefix bool) ([]string, map[string]structField) {
+	var column []string
+	m := make(map[string]structField)
+	eachField(value, func(field reflect.StructField, fieldValue reflect.Value) {
+		name := getColumnNameFromTag(field, ignorePrefix)
+		if _, ok := m[name]; !ok {
+			_, options := parseTag(field.Tag.Get("db"))
+			m[name] = structField{value: fieldValue, options: options}
+			column = append(column, name)
+		}
+	})
+	return column, m
+}
+
+// primaryKey returns conditions for fields tagged with pk.
+func primaryKey(value reflect.Value) []Builder {
+	var cond []Builder
+	column, m := structFields(value, true)
+	for _, col := range column {
+		if field := m[col]; field.isPK() {
+			cond = append(cond, Eq(col, field.Interface()))
+		}
+	}
+	return cond
+}
+
 func structMap(value reflect.Value, ignorePrefix bool) map[string]reflect.Value {
 	m := make(map[string]reflect.Value)
 	structValue(m, value, ignorePrefix)
@@ -63,10 +241,25 @@ func structMap(value reflect.Value, ignorePrefix bool) map[string]reflect.Value
 }
 
 var (
-	typeValuer = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+	typeValuer   = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+	typeNullTime = reflect.TypeOf(NullTime{})
+
+	// databaseNow is the current time of the database
+	databaseNow = Expr("NOW()")
 )
 
 func structValue(m map[string]reflect.Value, value reflect.Value, ignorePrefix bool) {
+	eachField(value, func(field reflect.StructField, fieldValue reflect.Value) {
+		column := getColumnNameFromTag(field, ignorePrefix)
+		if _, ok := m[column]; !ok {
+			m[column] = fieldValue
+		}
+	})
+}
+
+// eachField calls fn for each struct field mapped to a column in field order,
+// descending into nested structs.
+func eachField(value reflect.Value, fn func(field reflect.StructField, value reflect.Value)) {
 	if value.Type().Implements(typeValuer) {
 		return
 	}
@@ -76,25 +269,140 @@ func structValue(m map[string]reflect.Value, value reflect.Value, ignorePrefix b
 		if value.IsNil() {
 			return
 		}
-		structValue(m, value.Elem(), ignorePrefix)
+		eachField(value.Elem(), fn)
 	case reflect.Struct:
 		t := value.Type()
 		for i := 0; i < t.NumField(); i++ {
 			field := t.Field(i)
-			if field.PkgPath != "" && !field.Anonymous {
-				// unexported
+			if !isMappedField(field) {
 				continue
 			}
-			column := getColumnNameFromTag(field, ignorePrefix)
-			if column == "" {
+
+			fieldValue := value.Field(i)
+			fn(field, fieldValue)
+			if _, options := parseTag(field.Tag.Get("db")); !options.Contains("json") {
+				eachField(fieldValue, fn)
+			}
+		}
+	}
+}
+
+// isMappedField reports whether a struct field is mapped to a column.
+func isMappedField(field reflect.StructField) bool {
+	if field.PkgPath != "" && !field.Anonymous {
+		// unexported
+		return false
+	}
+	if isRelation(field) {
+		// has-many relations are loaded from joined rows
+		return false
+	}
+	if field.Type == typeTracked {
+		return false
+	}
+	return getColumnNameFromTag(field, false) != ""
+}
+
+// fieldIndex is the index sequence of a struct field mapped to a column
+type fieldIndex struct {
+	index   []int
+	options tagOptions
+}
+
+// value returns the field of a struct.
+// It returns false when a nested struct pointer is nil.
+func (f fieldIndex) value(v reflect.Value) (structField, bool) {
+	for i, x := range f.index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return structField{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return structField{value: v, options: f.options}, true
+}
+
+// fieldIndexCache caches fieldIndex maps by struct type
+var fieldIndexCache sync.Map
+
+// typeFields is same as structFieldMap with ignorePrefix, but maps columns
+// to field indexes of a struct type, so that it is computed once per type.
+func typeFields(t reflect.Type) map[string]fieldIndex {
+	if m, ok := fieldIndexCache.Load(t); ok {
+		return m.(map[string]fieldIndex)
+	}
+	m := make(map[string]fieldIndex)
+	eachFieldType(t, nil, make(map[reflect.Type]bool), func(field reflect.StructField, index []int) {
+		name := getColumnNameFromTag(field, true)
+		if _, ok := m[name]; !ok {
+			_, options := parseTag(field.Tag.Get("db"))
+			m[name] = fieldIndex{index: index, options: options}
+		}
+	})
+	fieldIndexCache.Store(t, m)
+	return m
+}
+
+// eachFieldType is same as eachField, but walks a struct type.
+// A struct type which refers to itself is visited only once on a path.
+func eachFieldType(t reflect.Type, index []int, visiting map[reflect.Type]bool, fn func(field reflect.StructField, index []int)) {
+	if t.Implements(typeValuer) {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		eachFieldType(t.Elem(), index, visiting, fn)
+	case reflect.Struct:
+		if visiting[t] {
+			return
+		}
+		visiting[t] = true
+		defer delete(visiting, t)
+
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !isMappedField(field) {
 				continue
 			}
 
-			fieldValue := value.Field(i)
-			if _, ok := m[column]; !ok {
-				m[column] = fieldValue
+			fieldIndex := append(append([]int(nil), index...), i)
+			fn(field, fieldIndex)
+			if _, options := parseTag(field.Tag.Get("db")); !options.Contains("json") {
+				eachFieldType(field.Type, fieldIndex, visiting, fn)
 			}
-			structValue(m, fieldValue, ignorePrefix)
 		}
 	}
 }
+
+// structColumns returns columns of a struct for a select statement.
+// A column with a table alias like `db:"p.id"` is returned as I("p.id"),
+// which is selected as `p__id`.
+func structColumns(value reflect.Value) []interface{} {
+	var column []interface{}
+	seen := make(map[string]bool)
+	eachField(value, func(field reflect.StructField, fieldValue reflect.Value) {
+		alias := getColumnNameFromTag(field, false)
+		if seen[alias] {
+			return
+		}
+		seen[alias] = true
+		tag, options := parseTag(field.Tag.Get("db"))
+		if (structField{value: fieldValue, options: options}).isContainer() {
+			return
+		}
+		if strings.Contains(tag, ".") {
+			column = append(column, I(tag))
+			return
+		}
+		column = append(column, alias)
+	})
+	return column
+}
+
+// isZero reports whether v is the zero value of its type.
+func isZero(v reflect.Value) bool {
+	return reflect.DeepEqual(v.Interface(), reflect.Zero(v.Type()).Interface())
+}
@@ -39,6 +39,32 @@ func TestGetColumnNameFromTag(t *testing.T) {
 	assert.Equal(t, "non_tag_field", getColumnNameFromTag(NonTagField, false))
 }
 
+type StructForTagOptions struct {
+	Payload map[string]int `db:"payload,json"`
+	Data    []int          `db:",json"`
+	Plain   string         `db:"plain"`
+}
+
+func TestGetColumnNameFromTagWithOptions(t *testing.T) {
+	rt := reflect.TypeOf(StructForTagOptions{})
+
+	assert.Equal(t, "payload", getColumnNameFromTag(rt.Field(0), false))
+	assert.Equal(t, "data", getColumnNameFromTag(rt.Field(1), false))
+	assert.Equal(t, "plain", getColumnNameFromTag(rt.Field(2), false))
+}
+
+func TestTagOptions(t *testing.T) {
+	name, options := parseTag("payload,json,omitempty")
+	assert.Equal(t, "payload", name)
+	assert.True(t, options.Contains("json"))
+	assert.True(t, options.Contains("omitempty"))
+	assert.False(t, options.Contains("js"))
+
+	name, options = parseTag("p.id")
+	assert.Equal(t, "p.id", name)
+	assert.False(t, options.Contains(""))
+}
+
 func TestSnakeCase(t *testing.T) {
 	for _, test := range []struct {
 		in   string
@@ -132,3 +158,30 @@ func TestStructMap(t *testing.T) {
 		}
 	}
 }
+
+type typeFieldsNode struct {
+	ID   int64 `db:"id"`
+	Next *typeFieldsNode
+}
+
+type typeFieldsTest struct {
+	*typeFieldsNode
+	Name    string            `db:"name,omitempty"`
+	Payload map[string]string `db:"payload,json"`
+}
+
+func TestTypeFields(t *testing.T) {
+	m := typeFields(reflect.TypeOf(typeFieldsTest{}))
+	assert.Len(t, m, 5)
+	assert.Equal(t, []int{0, 0}, m["id"].index)
+	assert.Equal(t, tagOptions("omitempty"), m["name"].options)
+	assert.Equal(t, m, typeFields(reflect.TypeOf(typeFieldsTest{})))
+
+	// nil embedded pointer
+	_, ok := m["id"].value(reflect.ValueOf(typeFieldsTest{}))
+	assert.False(t, ok)
+
+	field, ok := m["id"].value(reflect.ValueOf(typeFieldsTest{typeFieldsNode: &typeFieldsNode{ID: 1}}))
+	assert.True(t, ok)
+	assert.EqualValues(t, 1, field.value.Int())
+}
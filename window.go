@@ -0,0 +1,175 @@
+package fjord
+
+import "fmt"
+
+// Frame bounds for WindowStmt.Rows and WindowStmt.Range
+const (
+	UnboundedPreceding = "UNBOUNDED PRECEDING"
+	CurrentRow         = "CURRENT ROW"
+	UnboundedFollowing = "UNBOUNDED FOLLOWING"
+)
+
+// Preceding returns the frame bound `n PRECEDING`
+func Preceding(n uint64) string {
+	return fmt.Sprintf("%d PRECEDING", n)
+}
+
+// Following returns the frame bound `n FOLLOWING`
+func Following(n uint64) string {
+	return fmt.Sprintf("%d FOLLOWING", n)
+}
+
+// WindowStmt builds a window specification `(PARTITION BY ... ORDER BY ... frame)`
+type WindowStmt struct {
+	Partition []Builder
+	Order     []Builder
+	Frame     string
+}
+
+// Window creates a WindowStmt
+func Window() *WindowStmt {
+	return &WindowStmt{}
+}
+
+// Build builds the window specification in parentheses
+func (w *WindowStmt) Build(d Dialect, buf Buffer) error {
+	buf.WriteString("(")
+	sep := ""
+	if len(w.Partition) > 0 {
+		buf.WriteString("PARTITION BY ")
+		for i, part := range w.Partition {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			err := part.Build(d, buf)
+			if err != nil {
+				return err
+			}
+		}
+		sep = " "
+	}
+	if len(w.Order) > 0 {
+		buf.WriteString(sep)
+		buf.WriteString("ORDER BY ")
+		for i, order := range w.Order {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			err := order.Build(d, buf)
+			if err != nil {
+				return err
+			}
+		}
+		sep = " "
+	}
+	if w.Frame != "" {
+		buf.WriteString(sep)
+		buf.WriteString(w.Frame)
+	}
+	buf.WriteString(")")
+	return nil
+}
+
+// PartitionBy specifies columns for partitioning
+func (w *WindowStmt) PartitionBy(col ...string) *WindowStmt {
+	for _, part := range col {
+		w.Partition = append(w.Partition, Expr(part))
+	}
+	return w
+}
+
+// OrderBy specifies an expression for ordering in a partition
+func (w *WindowStmt) OrderBy(col string) *WindowStmt {
+	w.Order = append(w.Order, Expr(col))
+	return w
+}
+
+// OrderAsc specifies columns for ordering in a partition
+func (w *WindowStmt) OrderAsc(col string) *WindowStmt {
+	w.Order = append(w.Order, order(col, asc))
+	return w
+}
+
+// OrderDesc specifies columns for ordering in a partition
+func (w *WindowStmt) OrderDesc(col string) *WindowStmt {
+	w.Order = append(w.Order, order(col, desc))
+	return w
+}
+
+// Rows adds the frame `ROWS BETWEEN start AND end`, or `ROWS start` when end is empty
+func (w *WindowStmt) Rows(start, end string) *WindowStmt {
+	w.Frame = frame("ROWS", start, end)
+	return w
+}
+
+// Range adds the frame `RANGE BETWEEN start AND end`, or `RANGE start` when end is empty
+func (w *WindowStmt) Range(start, end string) *WindowStmt {
+	w.Frame = frame("RANGE", start, end)
+	return w
+}
+
+func frame(unit, start, end string) string {
+	if end == "" {
+		return unit + " " + start
+	}
+	return unit + " BETWEEN " + start + " AND " + end
+}
+
+// windowDef is a named window in `WINDOW name AS (...)`
+type windowDef struct {
+	name   string
+	window *WindowStmt
+}
+
+func (w windowDef) Build(d Dialect, buf Buffer) error {
+	buf.WriteString(d.QuoteIdent(w.name))
+	buf.WriteString(" AS ")
+	return w.window.Build(d, buf)
+}
+
+type over struct {
+	function interface{}
+	window   interface{}
+}
+
+// Over creates a window function call `function OVER window`.
+// function is a raw string such as "ROW_NUMBER()" or a Builder, and window is
+// a *WindowStmt or the name of a window defined with SelectStmt.Window.
+//
+//	fjord.Over("ROW_NUMBER()", fjord.Window().PartitionBy("dept").OrderDesc("salary")).As("rank")
+func Over(function, window interface{}) FuncExpr {
+	return &over{
+		function: function,
+		window:   window,
+	}
+}
+
+func (o *over) Build(d Dialect, buf Buffer) error {
+	switch function := o.function.(type) {
+	case string:
+		// FIXME: no quote ident
+		buf.WriteString(function)
+	case Builder:
+		err := function.Build(d, buf)
+		if err != nil {
+			return err
+		}
+	default:
+		return ErrNotSupported
+	}
+
+	buf.WriteString(" OVER ")
+	switch window := o.window.(type) {
+	case string:
+		buf.WriteString(d.QuoteIdent(window))
+	case *WindowStmt:
+		return window.Build(d, buf)
+	default:
+		return ErrNotSupported
+	}
+	return nil
+}
+
+func (o *over) As(alias string) Builder {
+	return as(o, alias)
+}
@@ -0,0 +1,86 @@
+package fjord
+
+import (
+	"testing"
+
+	"github.com/iktakahiro/fjord/dialect"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWindowStmt(t *testing.T) {
+	for _, test := range []struct {
+		window *WindowStmt
+		query  string
+	}{
+		{
+			window: Window(),
+			query:  "()",
+		},
+		{
+			window: Window().PartitionBy("dept", "team"),
+			query:  "(PARTITION BY dept, team)",
+		},
+		{
+			window: Window().PartitionBy("dept").OrderDesc("salary").OrderAsc("id"),
+			query:  "(PARTITION BY dept ORDER BY salary DESC, id ASC)",
+		},
+		{
+			window: Window().OrderBy("day").Rows(UnboundedPreceding, CurrentRow),
+			query:  "(ORDER BY day ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW)",
+		},
+		{
+			window: Window().OrderBy("day").Rows(Preceding(2), Following(1)),
+			query:  "(ORDER BY day ROWS BETWEEN 2 PRECEDING AND 1 FOLLOWING)",
+		},
+		{
+			window: Window().Range(UnboundedPreceding, ""),
+			query:  "(RANGE UNBOUNDED PRECEDING)",
+		},
+	} {
+		buf := NewBuffer()
+		err := test.window.Build(dialect.MySQL, buf)
+		assert.NoError(t, err)
+		assert.Equal(t, test.query, buf.String())
+	}
+}
+
+func TestOver(t *testing.T) {
+	for _, test := range []struct {
+		d     Dialect
+		stmt  *SelectStmt
+		query string
+	}{
+		{
+			d: dialect.MySQL,
+			stmt: Select("id", Over("ROW_NUMBER()", Window().PartitionBy("dept").OrderDesc("salary")).As("rank")).
+				From("employee"),
+			query: "SELECT id, ROW_NUMBER() OVER (PARTITION BY dept ORDER BY salary DESC) AS `rank` FROM employee",
+		},
+		{
+			d: dialect.PostgreSQL,
+			stmt: Select(
+				Over(Expr("SUM(amount)"), "w").As("total"),
+				Over(Expr("LAG(amount, ?)", 1), "w").As("prev"),
+			).From("sale").
+				Window("w", Window().OrderAsc("day").Rows(UnboundedPreceding, CurrentRow)).
+				OrderAsc("day"),
+			query: `SELECT SUM(amount) OVER "w" AS "total", LAG(amount, 1) OVER "w" AS "prev" FROM sale ` +
+				`WINDOW "w" AS (ORDER BY day ASC ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW) ORDER BY day ASC`,
+		},
+	} {
+		buf := NewBuffer()
+		err := test.stmt.Build(test.d, buf)
+		assert.NoError(t, err)
+		query, err := InterpolateForDialect(buf.String(), buf.Value(), test.d)
+		assert.NoError(t, err)
+		assert.Equal(t, test.query, query)
+	}
+}
+
+func TestOverNotSupported(t *testing.T) {
+	err := Over(1, Window()).Build(dialect.MySQL, NewBuffer())
+	assert.Equal(t, ErrNotSupported, err)
+
+	err = Over("ROW_NUMBER()", 1).Build(dialect.MySQL, NewBuffer())
+	assert.Equal(t, ErrNotSupported, err)
+}